@@ -0,0 +1,72 @@
+package boot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/slcjordan/poc"
+	"github.com/slcjordan/poc/config"
+	"github.com/slcjordan/poc/db"
+	"github.com/slcjordan/poc/pipeline"
+	"github.com/slcjordan/poc/rules"
+)
+
+// auditPageSize is how many games are listed at a time.
+const auditPageSize = 100
+
+// Audit checks the integrity of every saved game and writes a line to w for
+// each game that fails, with the category of its error so that corrupt games
+// can be told from games that could not be read. It returns how many games
+// failed.
+func Audit(ctx context.Context, pool db.Pool, w io.Writer) (int, error) {
+	search := pipeline.ListGames{&db.Search{Pool: pool}}
+	check := pipeline.LookupGame{&db.Lookup{Pool: pool}, rules.CheckIntegrity{}}
+
+	var failed int
+	var list poc.ListGames
+	list.Cursor.Limit = auditPageSize
+	for {
+		var err error
+		list, err = search.CallListGames(ctx, list)
+		if err != nil {
+			return failed, err
+		}
+		for _, summary := range list.Games {
+			var game poc.LookupGame
+			game.SavedGameDetail.GameID = summary.GameID
+			_, err := check.CallLookupGame(ctx, game)
+			var pocErr poc.Error
+			if errors.As(err, &pocErr) && pocErr.Category == poc.UnavailableError {
+				return failed, err
+			}
+			if err != nil {
+				failed++
+				fmt.Fprintf(w, "game %d: %s: %s\n", summary.GameID, pocErr.Category, err)
+			}
+		}
+		if len(list.Games) < auditPageSize {
+			return failed, nil
+		}
+		list.Cursor.Offset += auditPageSize
+	}
+}
+
+// MustAuditFromConfig parses config, audits every saved game and exits with a
+// non-zero status if any game failed.
+func MustAuditFromConfig() {
+	config.MustParse()
+	pool := PGXConnect(config.DB.ConnString)
+
+	failed, err := Audit(context.Background(), pool, os.Stdout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "could not finish the audit: %s\n", err)
+		os.Exit(2)
+	}
+	fmt.Fprintf(os.Stdout, "%d games failed\n", failed)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,205 @@
+package boot_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+
+	gomock "github.com/golang/mock/gomock"
+	"github.com/pashagolub/pgxmock"
+
+	"github.com/slcjordan/poc"
+	"github.com/slcjordan/poc/boot"
+	"github.com/slcjordan/poc/rules"
+	"github.com/slcjordan/poc/test/logger"
+	"github.com/slcjordan/poc/test/mocks"
+)
+
+type pgxmockConn struct {
+	pgxmock.PgxConnIface
+}
+
+func (p pgxmockConn) Release() {}
+
+// NewAuditTestPool hands out the same mock conn for every page of games and
+// every game looked up.
+func NewAuditTestPool(t *testing.T, expect func(pgxmock.PgxConnIface)) *mocks.MockPool {
+	ctrl := gomock.NewController(t)
+	pool := mocks.NewMockPool(ctrl)
+	conn, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("could not create mock conn: %s", err)
+	}
+	expect(conn)
+	t.Cleanup(func() {
+		err := conn.ExpectationsWereMet()
+		if err != nil {
+			t.Errorf("unmet db expectations: %s", err)
+		}
+	})
+	pool.
+		EXPECT().
+		Acquire(gomock.Any()).
+		Return(pgxmockConn{conn}, nil).
+		AnyTimes()
+	return pool
+}
+
+var lookupColumns = []string{
+	"id", "score", "max_times_through_deck", "empty_column_fill", "scoring",
+	"draw_count", "status", "hint_penalty", "allow_undo", "undo_penalty", "seed", "salt",
+	"commitment", "family", "suit_count", "pile_count", "jokers", "ruleset", "previous_game_id",
+	"hints_used", "pile_nums", "pile_indexes", "suits", "indexes", "positions",
+	"move_numbers", "old_pile_nums", "old_pile_indexes", "old_pile_positions",
+	"new_pile_nums", "new_pile_indexes", "new_pile_positions", "undone", "board_hashes",
+	"slot_pile_nums", "slot_rows", "slot_columns", "cover_pile_nums", "covered_by",
+}
+
+// dealt is a freshly dealt Klondike game.
+func dealt(t *testing.T) poc.SavedGameDetail {
+	start, err := rules.Shuffle{Source: rand.New(rand.NewSource(1))}.CallStartGame(context.Background(), poc.StartGame{Seed: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return start.SavedGameDetail
+}
+
+// lookupRows is the row the lookup query returns for game.
+func lookupRows(gameID int64, game poc.SavedGameDetail) *pgxmock.Rows {
+	var pileNums, pileIndexes, suits, indexes []int16
+	var positions []int32
+	for pileNum, pile := range game.Board.Piles {
+		for pileIndex, card := range pile {
+			pileNums = append(pileNums, int16(pileNum))
+			pileIndexes = append(pileIndexes, int16(pileIndex))
+			suits = append(suits, int16(card.Card.Suit))
+			indexes = append(indexes, int16(card.Card.Index))
+			positions = append(positions, int32(card.Position))
+		}
+	}
+	return pgxmock.NewRows(lookupColumns).AddRow(
+		gameID, int32(0), int32(0), int16(0), int16(0), int32(1), int16(0), int32(0), false, int32(0),
+		game.Seed, "", "", int16(game.Variant.Family), int32(0), int16(len(game.Board.Piles)), int32(0), "klondike", int64(0), int32(0),
+		pileNums, pileIndexes, suits, indexes, positions,
+		[]int32{}, []int16{}, []int16{}, []int16{}, []int16{}, []int16{}, []int16{}, []bool{}, []int64{},
+		[]int16{}, []int16{}, []int16{}, []int16{}, []int16{},
+	)
+}
+
+// expectGames lists gameIDs a page at a time and looks each of them up,
+// calling lookup for the rows of each game.
+func expectGames(conn pgxmock.PgxConnIface, gameIDs []int64, lookup func(int64, *pgxmock.ExpectedQuery)) {
+	const pageSize = 100
+	for offset := 0; ; offset += pageSize {
+		page := gameIDs[offset:]
+		if len(page) > pageSize {
+			page = page[:pageSize]
+		}
+		rows := pgxmock.NewRows([]string{"id", "score"})
+		for _, gameID := range page {
+			rows.AddRow(gameID, int32(0))
+		}
+		conn.ExpectQuery("SELECT id, score").
+			WithArgs(int32(pageSize), int32(offset)).
+			WillReturnRows(rows)
+		for _, gameID := range page {
+			lookup(gameID, conn.ExpectQuery("SELECT game.id").WithArgs(gameID))
+		}
+		if len(page) < pageSize {
+			return
+		}
+	}
+}
+
+func TestAudit(t *testing.T) {
+	logger.RegisterVerbose(t)
+	game := dealt(t)
+	corrupt := game
+	corrupt.Board.Piles = append([][]poc.PositionedCard(nil), game.Board.Piles...)
+	corrupt.Board.Piles[0] = corrupt.Board.Piles[0][1:] // a card has gone missing
+
+	for _, testCase := range []struct {
+		Desc    string
+		GameIDs []int64
+		Lookup  func(int64, *pgxmock.ExpectedQuery)
+		Failed  int
+		Output  string
+	}{
+		{
+			Desc: "every page of games is checked",
+			GameIDs: func() []int64 {
+				var result []int64
+				for gameID := int64(1); gameID <= 201; gameID++ {
+					result = append(result, gameID)
+				}
+				return result
+			}(),
+			Lookup: func(gameID int64, q *pgxmock.ExpectedQuery) {
+				q.WillReturnRows(lookupRows(gameID, game))
+			},
+		},
+		{
+			Desc:    "a game that can't be looked up fails and the audit goes on",
+			GameIDs: []int64{1, 2, 3},
+			Lookup: func(gameID int64, q *pgxmock.ExpectedQuery) {
+				if gameID == 2 {
+					q.WillReturnError(errors.New("check that this error fails only game 2"))
+					return
+				}
+				q.WillReturnRows(lookupRows(gameID, game))
+			},
+			Failed: 1,
+			Output: "game 2: UnknownError: could not lookup game\n",
+		},
+		{
+			Desc:    "a corrupt board fails its integrity check",
+			GameIDs: []int64{1, 2, 3},
+			Lookup: func(gameID int64, q *pgxmock.ExpectedQuery) {
+				if gameID == 3 {
+					q.WillReturnRows(lookupRows(gameID, corrupt))
+					return
+				}
+				q.WillReturnRows(lookupRows(gameID, game))
+			},
+			Failed: 1,
+			Output: "game 3: IntegrityError: corrupt board: missing the",
+		},
+	} {
+		t.Run(testCase.Desc, func(t *testing.T) {
+			pool := NewAuditTestPool(t, func(conn pgxmock.PgxConnIface) {
+				expectGames(conn, testCase.GameIDs, testCase.Lookup)
+			})
+			var w bytes.Buffer
+			failed, err := boot.Audit(context.Background(), pool, &w)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if failed != testCase.Failed {
+				t.Errorf("expected %d failed games but got %d", testCase.Failed, failed)
+			}
+			if !strings.HasPrefix(w.String(), testCase.Output) || (testCase.Output == "") != (w.Len() == 0) {
+				t.Errorf("expected output starting %q but got %q", testCase.Output, w.String())
+			}
+		})
+	}
+}
+
+func TestAuditUnavailable(t *testing.T) {
+	logger.RegisterVerbose(t)
+	ctrl := gomock.NewController(t)
+	pool := mocks.NewMockPool(ctrl)
+	pool.
+		EXPECT().
+		Acquire(gomock.Any()).
+		Return(nil, errors.New("check that this error stops the audit"))
+
+	var w bytes.Buffer
+	_, err := boot.Audit(context.Background(), pool, &w)
+	var pocErr poc.Error
+	if !errors.As(err, &pocErr) || pocErr.Category != poc.UnavailableError {
+		t.Errorf("expected an unavailable error but got %v", err)
+	}
+}
@@ -2,9 +2,8 @@ package boot
 
 import (
 	"context"
-	"math/rand"
+	"crypto/rand"
 	"net/http"
-	"time"
 
 	chi "github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -69,16 +68,25 @@ func MustServeFromConfig() {
 
 // APIServer connects to database; sets up routes and handlers.
 func APIServer(pool db.Pool) chi.Router {
-	v1HydrateParams := router.V1HydrateURLAndQueryParams{OffsetKey: "offset", LimitKey: "limit"}
+	v1HydrateParams := router.V1HydrateURLAndQueryParams{OffsetKey: "offset", LimitKey: "limit", StreamKey: "stream"}
 	save := &db.Save{Pool: pool}
 	search := &db.Search{Pool: pool}
 	lookup := &db.Lookup{Pool: pool}
+	solve := rules.Solve{MaxNodes: config.Solver.MaxNodes, Timeout: config.Solver.Timeout}
+	status := rules.Status{MaxPositions: config.Status.MaxPositions}
 
 	return router.New(router.V1Handlers{
 		PostGameStart: handler.StartGame{
 			Encoding: json.V1{},
 			Pipeline: pipeline.StartGame{
-				rules.Shuffle{Source: rand.NewSource(time.Now().UnixNano())},
+				lookup,
+				rules.WinnableDeal{
+					Shuffle:  rules.Shuffle{Source: rand.Reader},
+					Solve:    solve,
+					MaxDeals: 10,
+				},
+				rules.Score{},
+				status,
 				save,
 				rules.NextMove{},
 			}.UseEach(
@@ -90,11 +98,101 @@ func APIServer(pool db.Pool) chi.Router {
 			Pipeline: pipeline.PerformMove{
 				v1HydrateParams,
 				lookup,
+				rules.CheckIntegrity{},
 				rules.Validate{},
+				rules.Apply{},
+				rules.Score{},
+				status,
+				save,
+				rules.NextMove{},
+			},
+		},
+		GetGameByID: handler.LookupGame{
+			Encoding: json.V1{},
+			Pipeline: pipeline.LookupGame{
+				v1HydrateParams,
+				lookup,
+				rules.NextMove{},
+			},
+		},
+		PostGameByIDResign: handler.ResignGame{
+			Encoding: json.V1{},
+			Pipeline: pipeline.ResignGame{
+				v1HydrateParams,
+				lookup,
+				rules.Resign{},
+				save,
+				rules.NextMove{},
+			},
+		},
+		PostGameByIDAutoComplete: handler.AutoComplete{
+			Encoding: json.V1{},
+			Pipeline: pipeline.AutoComplete{
+				v1HydrateParams,
+				lookup,
+				rules.AutoComplete{},
+				save,
+				rules.NextMove{},
+			},
+		},
+		PostGameByIDSolve: handler.SolveGame{
+			Encoding: json.V1{},
+			Pipeline: pipeline.SolveGame{
+				v1HydrateParams,
+				lookup,
+				solve,
+			},
+		},
+		PostGameByIDHint: handler.Hint{
+			Encoding: json.V1{},
+			Pipeline: pipeline.Hint{
+				v1HydrateParams,
+				lookup,
+				rules.Hint{},
+				rules.Score{},
+				save,
+			},
+		},
+		PostGameByIDUndo: handler.UndoMove{
+			Encoding: json.V1{},
+			Pipeline: pipeline.UndoMove{
+				v1HydrateParams,
+				lookup,
+				rules.Undo{},
+				rules.Score{},
+				status,
+				save,
+				rules.NextMove{},
+			},
+		},
+		PostGameByIDRedo: handler.RedoMove{
+			Encoding: json.V1{},
+			Pipeline: pipeline.RedoMove{
+				v1HydrateParams,
+				lookup,
+				rules.Redo{},
+				rules.Score{},
+				status,
 				save,
 				rules.NextMove{},
 			},
 		},
+		GetGameByIDFairness: handler.Fairness{
+			Encoding: json.V1{},
+			Pipeline: pipeline.Fairness{
+				v1HydrateParams,
+				lookup,
+				rules.Reveal{},
+			},
+		},
+		GetGameByIDAtMoveNumber: handler.Replay{
+			Encoding: json.V1{},
+			Pipeline: pipeline.Replay{
+				v1HydrateParams,
+				lookup,
+				rules.Replay{},
+			},
+		},
 		GetGameList: handler.ListGames{
 			Encoding: json.V1{},
 			Pipeline: pipeline.ListGames{
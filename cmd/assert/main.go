@@ -266,7 +266,7 @@ func (a arrayTypeDef) WriteTo(w io.Writer) (int64, error) {
 type %s struct {
 	assertion      *Assertion
 	lengthCheckers []IntChecker
-	nth            map[int]%s
+	nth            map[int]*%s
 
 	ForEach %s
 }`, camel(arrayName(a.typ)), camel(arrayName(a.next)), camel(arrayName(a.next)))
@@ -275,20 +275,20 @@ type %s struct {
 func new%s(assertion *Assertion) %s {
 	return %s {
 		assertion: assertion,
-		nth:       make(map[int]%s),
+		nth:       make(map[int]*%s),
 		ForEach:   new%s(assertion),
 	}
 }`, camel(arrayName(a.typ)), camel(arrayName(a.typ)), camel(arrayName(a.typ)), camel(arrayName(a.next)), camel(arrayName(a.next)))
 	mw.Fprintf(`
 
-func (a *%s) Nth(i int) %s {
+func (a *%s) Nth(i int) *%s {
 	prev, ok := a.nth[i]
 	if ok {
 		return prev
 	}
 	result := new%s(a.assertion)
-	a.nth[i] = result
-	return result
+	a.nth[i] = &result
+	return &result
 }`, camel(arrayName(a.typ)), camel(arrayName(a.next)), camel(arrayName(a.next)))
 	mw.Fprintf(`
 
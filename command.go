@@ -16,6 +16,51 @@ type PerformMoveCaller interface {
 	CallPerformMove(context.Context, PerformMove) (PerformMove, error)
 }
 
+// LookupGameCaller is a lookup game command.
+type LookupGameCaller interface {
+	CallLookupGame(context.Context, LookupGame) (LookupGame, error)
+}
+
+// FairnessCaller is a fairness command.
+type FairnessCaller interface {
+	CallFairness(context.Context, Fairness) (Fairness, error)
+}
+
+// ReplayCaller is a replay command.
+type ReplayCaller interface {
+	CallReplay(context.Context, Replay) (Replay, error)
+}
+
+// RedoMoveCaller is a redo move command.
+type RedoMoveCaller interface {
+	CallRedoMove(context.Context, RedoMove) (RedoMove, error)
+}
+
+// UndoMoveCaller is a undo move command.
+type UndoMoveCaller interface {
+	CallUndoMove(context.Context, UndoMove) (UndoMove, error)
+}
+
+// HintCaller is a hint command.
+type HintCaller interface {
+	CallHint(context.Context, Hint) (Hint, error)
+}
+
+// SolveGameCaller is a solve game command.
+type SolveGameCaller interface {
+	CallSolveGame(context.Context, SolveGame) (SolveGame, error)
+}
+
+// AutoCompleteCaller is an auto complete command.
+type AutoCompleteCaller interface {
+	CallAutoComplete(context.Context, AutoComplete) (AutoComplete, error)
+}
+
+// ResignGameCaller is a resign game command.
+type ResignGameCaller interface {
+	CallResignGame(context.Context, ResignGame) (ResignGame, error)
+}
+
 // ListGamesCaller is a list game command.
 type ListGamesCaller interface {
 	CallListGames(context.Context, ListGames) (ListGames, error)
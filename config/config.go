@@ -1,5 +1,7 @@
 package config
 
+import "time"
+
 // DB options.
 var DB struct {
 	ConnString string
@@ -9,3 +11,19 @@ var DB struct {
 var HTTP struct {
 	ListenAddress string
 }
+
+// Status options.
+var Status = struct {
+	MaxPositions int
+}{
+	MaxPositions: 5000,
+}
+
+// Solver options.
+var Solver = struct {
+	MaxNodes int
+	Timeout  time.Duration
+}{
+	MaxNodes: 200000,
+	Timeout:  10 * time.Second,
+}
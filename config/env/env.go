@@ -1,7 +1,10 @@
 package env
 
 import (
+	"fmt"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/slcjordan/poc/config"
 )
@@ -22,5 +25,29 @@ func (p parser) ParseConfig() error {
 	if listenAddress != "" {
 		config.HTTP.ListenAddress = listenAddress
 	}
+	maxPositions := os.Getenv("STATUS_MAX_POSITIONS")
+	if maxPositions != "" {
+		val, err := strconv.Atoi(maxPositions)
+		if err != nil {
+			return fmt.Errorf("parsing STATUS_MAX_POSITIONS: %w", err)
+		}
+		config.Status.MaxPositions = val
+	}
+	maxNodes := os.Getenv("SOLVER_MAX_NODES")
+	if maxNodes != "" {
+		val, err := strconv.Atoi(maxNodes)
+		if err != nil {
+			return fmt.Errorf("parsing SOLVER_MAX_NODES: %w", err)
+		}
+		config.Solver.MaxNodes = val
+	}
+	timeout := os.Getenv("SOLVER_TIMEOUT")
+	if timeout != "" {
+		val, err := time.ParseDuration(timeout)
+		if err != nil {
+			return fmt.Errorf("parsing SOLVER_TIMEOUT: %w", err)
+		}
+		config.Solver.Timeout = val
+	}
 	return nil
 }
@@ -3,6 +3,9 @@ package db
 import (
 	"context"
 	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v4"
 
 	"github.com/slcjordan/poc"
 	"github.com/slcjordan/poc/db/sqlc"
@@ -14,18 +17,230 @@ type Lookup struct {
 	Pool Pool
 }
 
-// CallPerformMove expects move.Input.GameID to be set.
-func (l *Lookup) CallPerformMove(ctx context.Context, move poc.PerformMove) (poc.PerformMove, error) {
+// toSavedGameDetail rebuilds the board and its slots, history and redo stack
+// from the aggregated columns of a game detail row. Undone moves always come
+// after the rest of the history, so the redo stack is built in reverse with
+// the next move to redo on top. Every move in a group carries the hash of the
+// position after the group.
+func toSavedGameDetail(row sqlc.LookupGameDetailRow) (poc.SavedGameDetail, error) {
+	var result poc.SavedGameDetail
+
+	result.GameID = row.ID
+	result.Board.Score = row.Score
+	result.Variant.MaxTimesThroughDeck = row.MaxTimesThroughDeck
+	result.Variant.EmptyColumnFill = poc.ColumnFill(row.EmptyColumnFill)
+	result.Variant.Scoring = poc.Scoring(row.Scoring)
+	result.Variant.DrawCount = row.DrawCount
+	result.Status = poc.GameStatus(row.Status)
+	result.Variant.HintPenalty = row.HintPenalty
+	result.Variant.AllowUndo = row.AllowUndo
+	result.Variant.UndoPenalty = row.UndoPenalty
+	result.Seed = row.Seed
+	result.Salt = row.Salt
+	result.Commitment = row.Commitment
+	result.Variant.Family = poc.Family(row.Family)
+	result.Variant.Suits = row.SuitCount
+	result.Variant.Jokers = row.Jokers
+	result.Variant.Name = row.Ruleset
+	result.PreviousGameID = row.PreviousGameID
+	result.HintsUsed = row.HintsUsed
+
+	result.Board.Piles = make([][]poc.PositionedCard, row.PileCount)
+	for i, pileNum := range row.PileNums {
+		if int(pileNum) >= len(result.Board.Piles) {
+			return result, fmt.Errorf("pile %d is out of range", pileNum)
+		}
+		if int(row.PileIndexes[i]) != len(result.Board.Piles[pileNum]) {
+			return result, fmt.Errorf("pile %d is missing a card at index %d", pileNum, len(result.Board.Piles[pileNum]))
+		}
+		result.Board.Piles[pileNum] = append(result.Board.Piles[pileNum], poc.PositionedCard{
+			Position: poc.Position(row.Positions[i]),
+			Card: poc.Card{
+				Suit:  poc.Suit(row.Suits[i]),
+				Index: poc.Index(row.Indexes[i]),
+			},
+		})
+	}
+
+	for i, pileNum := range row.SlotPileNums {
+		result.Board.Slots = append(result.Board.Slots, poc.Slot{
+			PileNum: int(pileNum),
+			Row:     int(row.SlotRows[i]),
+			Column:  int(row.SlotColumns[i]),
+		})
+	}
+	for i, pileNum := range row.CoverPileNums {
+		if int(pileNum) >= len(result.Board.Piles) || int(row.CoveredBy[i]) >= len(result.Board.Piles) {
+			return result, fmt.Errorf("pile %d is covered by pile %d which is out of range", pileNum, row.CoveredBy[i])
+		}
+		result.Board.Covers = append(result.Board.Covers, poc.Cover{
+			PileNum:   int(pileNum),
+			CoveredBy: int(row.CoveredBy[i]),
+		})
+	}
+
+	var undone [][]poc.Move
+	for i, moveNumber := range row.MoveNumbers {
+		groups := &result.History
+		if i < len(row.Undone) && row.Undone[i] {
+			groups = &undone
+		}
+		if i == 0 || moveNumber != row.MoveNumbers[i-1] {
+			*groups = append(*groups, nil)
+			if groups == &result.History && i < len(row.BoardHashes) {
+				result.Hashes = append(result.Hashes, poc.Hash(row.BoardHashes[i]))
+			}
+		}
+		last := len(*groups) - 1
+		(*groups)[last] = append((*groups)[last], poc.Move{
+			OldPileNum:      int(row.OldPileNums[i]),
+			OldPileIndex:    int(row.OldPileIndexes[i]),
+			OldPilePosition: poc.Position(row.OldPilePositions[i]),
+			NewPileNum:      int(row.NewPileNums[i]),
+			NewPileIndex:    int(row.NewPileIndexes[i]),
+			NewPilePosition: poc.Position(row.NewPilePositions[i]),
+		})
+	}
+	for i := len(undone) - 1; i >= 0; i-- {
+		result.Redo = append(result.Redo, undone[i])
+	}
+	return result, nil
+}
+
+// lookupGameDetail fetches a game by id.
+func (l *Lookup) lookupGameDetail(ctx context.Context, gameID int64) (poc.SavedGameDetail, error) {
 	conn, err := l.Pool.Acquire(ctx)
 	if err != nil {
 		logger.Infof(ctx, "could not acquire connection: %s", err)
-		return move, poc.Error{Actual: errors.New("db unavailable"), Category: poc.UnavailableError}
+		return poc.SavedGameDetail{}, poc.Error{Actual: errors.New("db unavailable"), Category: poc.UnavailableError}
 	}
 	defer conn.Release()
-	_, err = sqlc.New(conn).LookupGameDetail(ctx, move.SavedGameDetail.GameID)
+	row, err := sqlc.New(conn).LookupGameDetail(ctx, gameID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return poc.SavedGameDetail{}, poc.Error{Actual: errors.New("could not find game"), Category: poc.NotFoundError}
+	}
+	if err != nil {
+		logger.Errorf(ctx, "could not lookup game %d: %s", gameID, err)
+		return poc.SavedGameDetail{}, poc.Error{Actual: errors.New("could not lookup game"), Category: poc.UnknownError}
+	}
+	result, err := toSavedGameDetail(row)
 	if err != nil {
-		logger.Errorf(ctx, "could not lookup game %d: %s", move.SavedGameDetail.GameID, err)
-		return move, poc.Error{Actual: errors.New("could not find game"), Category: poc.UnknownError}
+		logger.Errorf(ctx, "could not read game %d: %s", gameID, err)
+		return poc.SavedGameDetail{}, poc.Error{Actual: errors.New("could not lookup game"), Category: poc.UnknownError}
 	}
+	return result, nil
+}
+
+// CallStartGame fetches start.Previous when
+// start.SavedGameDetail.PreviousGameID is set.
+func (l *Lookup) CallStartGame(ctx context.Context, start poc.StartGame) (poc.StartGame, error) {
+	if start.SavedGameDetail.PreviousGameID == 0 {
+		return start, nil
+	}
+	saved, err := l.lookupGameDetail(ctx, start.SavedGameDetail.PreviousGameID)
+	if err != nil {
+		return start, err
+	}
+	start.Previous = saved
+	return start, nil
+}
+
+// CallPerformMove expects move.SavedGameDetail.GameID to be set.
+func (l *Lookup) CallPerformMove(ctx context.Context, move poc.PerformMove) (poc.PerformMove, error) {
+	saved, err := l.lookupGameDetail(ctx, move.SavedGameDetail.GameID)
+	if err != nil {
+		return move, err
+	}
+	move.SavedGameDetail = saved
 	return move, nil
 }
+
+// CallAutoComplete expects game.SavedGameDetail.GameID to be set.
+func (l *Lookup) CallAutoComplete(ctx context.Context, game poc.AutoComplete) (poc.AutoComplete, error) {
+	saved, err := l.lookupGameDetail(ctx, game.SavedGameDetail.GameID)
+	if err != nil {
+		return game, err
+	}
+	game.SavedGameDetail = saved
+	return game, nil
+}
+
+// CallSolveGame expects game.SavedGameDetail.GameID to be set.
+func (l *Lookup) CallSolveGame(ctx context.Context, game poc.SolveGame) (poc.SolveGame, error) {
+	saved, err := l.lookupGameDetail(ctx, game.SavedGameDetail.GameID)
+	if err != nil {
+		return game, err
+	}
+	game.SavedGameDetail = saved
+	return game, nil
+}
+
+// CallHint expects game.SavedGameDetail.GameID to be set.
+func (l *Lookup) CallHint(ctx context.Context, game poc.Hint) (poc.Hint, error) {
+	saved, err := l.lookupGameDetail(ctx, game.SavedGameDetail.GameID)
+	if err != nil {
+		return game, err
+	}
+	game.SavedGameDetail = saved
+	return game, nil
+}
+
+// CallResignGame expects game.SavedGameDetail.GameID to be set.
+func (l *Lookup) CallResignGame(ctx context.Context, game poc.ResignGame) (poc.ResignGame, error) {
+	saved, err := l.lookupGameDetail(ctx, game.SavedGameDetail.GameID)
+	if err != nil {
+		return game, err
+	}
+	game.SavedGameDetail = saved
+	return game, nil
+}
+
+// CallUndoMove expects game.SavedGameDetail.GameID to be set.
+func (l *Lookup) CallUndoMove(ctx context.Context, game poc.UndoMove) (poc.UndoMove, error) {
+	saved, err := l.lookupGameDetail(ctx, game.SavedGameDetail.GameID)
+	if err != nil {
+		return game, err
+	}
+	game.SavedGameDetail = saved
+	return game, nil
+}
+
+// CallRedoMove expects game.SavedGameDetail.GameID to be set.
+func (l *Lookup) CallRedoMove(ctx context.Context, game poc.RedoMove) (poc.RedoMove, error) {
+	saved, err := l.lookupGameDetail(ctx, game.SavedGameDetail.GameID)
+	if err != nil {
+		return game, err
+	}
+	game.SavedGameDetail = saved
+	return game, nil
+}
+
+// CallFairness expects game.SavedGameDetail.GameID to be set.
+func (l *Lookup) CallFairness(ctx context.Context, game poc.Fairness) (poc.Fairness, error) {
+	saved, err := l.lookupGameDetail(ctx, game.SavedGameDetail.GameID)
+	if err != nil {
+		return game, err
+	}
+	game.SavedGameDetail = saved
+	return game, nil
+}
+
+// CallReplay expects game.SavedGameDetail.GameID to be set.
+func (l *Lookup) CallReplay(ctx context.Context, game poc.Replay) (poc.Replay, error) {
+	saved, err := l.lookupGameDetail(ctx, game.SavedGameDetail.GameID)
+	if err != nil {
+		return game, err
+	}
+	game.SavedGameDetail = saved
+	return game, nil
+}
+
+// CallLookupGame expects game.SavedGameDetail.GameID to be set.
+func (l *Lookup) CallLookupGame(ctx context.Context, game poc.LookupGame) (poc.LookupGame, error) {
+	saved, err := l.lookupGameDetail(ctx, game.SavedGameDetail.GameID)
+	if err != nil {
+		return game, err
+	}
+	game.SavedGameDetail = saved
+	return game, nil
+}
@@ -0,0 +1,223 @@
+package db_test
+
+import (
+	"errors"
+	"testing"
+
+	gomock "github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/slcjordan/poc"
+	"github.com/slcjordan/poc/db"
+	"github.com/slcjordan/poc/test/assert"
+	"github.com/slcjordan/poc/test/harness"
+	"github.com/slcjordan/poc/test/logger"
+	"github.com/slcjordan/poc/test/mocks"
+)
+
+func NewLookupTestPool(t *testing.T, row mockRow) *mocks.MockPool {
+	ctrl := gomock.NewController(t)
+	pool := mocks.NewMockPool(ctrl)
+	conn := mocks.NewMockConn(ctrl)
+	conn.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).Return(row)
+	conn.EXPECT().Release()
+	pool.
+		EXPECT().
+		Acquire(gomock.Any()).
+		Return(conn, nil)
+	return pool
+}
+
+func TestLookup(t *testing.T) {
+	logger.RegisterVerbose(t)
+	harness.PerformMove{
+		{
+			Desc: "hydrates board and history",
+			Command: &db.Lookup{
+				NewLookupTestPool(t, mockRow{values: []interface{}{
+					int64(2021),                      // id
+					int32(-52),                       // score
+					int32(3),                         // max_times_through_deck
+					int16(poc.AnyCardFill),           // empty_column_fill
+					int16(poc.VegasScoring),          // scoring
+					int32(3),                         // draw_count
+					int16(poc.Won),                   // status
+					int32(10),                        // hint_penalty
+					true,                             // allow_undo
+					int32(7),                         // undo_penalty
+					int64(12345),                     // seed
+					"73616c74",                       // salt
+					"c0ffee",                         // commitment
+					int16(poc.Spider),                // family
+					int32(2),                         // suit_count
+					int16(19),                        // pile_count
+					int32(2),                         // jokers
+					"klondike",                       // ruleset
+					int64(0),                         // previous_game_id
+					int32(2),                         // hints_used
+					[]int16{0, 0, 2},                 // pile_nums
+					[]int16{0, 1, 0},                 // pile_indexes
+					[]int16{1, 2, 3},                 // suits
+					[]int16{1, 2, 3},                 // indexes
+					[]int32{0, 0, int32(poc.FaceUp)}, // positions
+					[]int32{1, 2, 2},                 // move_numbers
+					[]int16{0, 2, 2},                 // old_pile_nums
+					[]int16{2, 1, 2},                 // old_pile_indexes
+					[]int16{0, 1, 1},                 // old_pile_positions
+					[]int16{1, 3, 3},                 // new_pile_nums
+					[]int16{0, 0, 1},                 // new_pile_indexes
+					[]int16{1, 1, 1},                 // new_pile_positions
+					[]bool{false, false, false},      // undone
+					[]int64{-5, 6, 6},                // board_hashes
+					[]int16{2, 3, 4},                 // slot_pile_nums
+					[]int16{0, 1, 1},                 // slot_rows
+					[]int16{1, 0, 2},                 // slot_columns
+					[]int16{2, 2},                    // cover_pile_nums
+					[]int16{3, 4},                    // covered_by
+				}}),
+			},
+			Result: func() *assert.Assertion {
+				a := assert.New().NoError()
+				a.PerformMove.SavedGameDetail.GameID(assert.Equals(2021))
+				a.PerformMove.SavedGameDetail.Board.Score(assert.Equals(-52))
+				a.PerformMove.SavedGameDetail.Variant.MaxTimesThroughDeck(assert.Equals(3))
+				a.PerformMove.SavedGameDetail.Variant.EmptyColumnFill.Uint8(assert.Equals(poc.AnyCardFill))
+				a.PerformMove.SavedGameDetail.Variant.Scoring.Uint8(assert.Equals(poc.VegasScoring))
+				a.PerformMove.SavedGameDetail.Variant.DrawCount(assert.Equals(3))
+				a.PerformMove.SavedGameDetail.Status.Uint8(assert.Equals(poc.Won))
+				a.PerformMove.SavedGameDetail.Variant.HintPenalty(assert.Equals(10))
+				a.PerformMove.SavedGameDetail.Variant.AllowUndo(assert.EqualsBool(true))
+				a.PerformMove.SavedGameDetail.Variant.UndoPenalty(assert.Equals(7))
+				a.PerformMove.SavedGameDetail.Variant.Family.Uint8(assert.Equals(poc.Spider))
+				a.PerformMove.SavedGameDetail.Variant.Suits(assert.Equals(2))
+				a.PerformMove.SavedGameDetail.Variant.Jokers(assert.Equals(2))
+				a.PerformMove.SavedGameDetail.Variant.Name(assert.EqualsString("klondike"))
+				a.PerformMove.SavedGameDetail.Board.Piles.Length(assert.Equals(19))
+				a.PerformMove.SavedGameDetail.Seed(assert.Equals(12345))
+				a.PerformMove.SavedGameDetail.HintsUsed(assert.Equals(2))
+				a.PerformMove.SavedGameDetail.Board.Piles.Nth(0).Length(assert.Equals(2))
+				a.PerformMove.SavedGameDetail.Board.Piles.Nth(2).Length(assert.Equals(1))
+				a.PerformMove.SavedGameDetail.Board.Piles.Nth(2).Nth(0).Card.Suit.Uint8(assert.Equals(poc.Diamonds))
+				a.PerformMove.SavedGameDetail.Board.Slots.Length(assert.Equals(3))
+				a.PerformMove.SavedGameDetail.Board.Slots.Nth(2).Column(assert.Equals(2))
+				a.PerformMove.SavedGameDetail.Board.Covers.Length(assert.Equals(2))
+				a.PerformMove.SavedGameDetail.Board.Covers.Nth(1).CoveredBy(assert.Equals(4))
+				a.PerformMove.SavedGameDetail.History.Length(assert.Equals(2))
+				a.PerformMove.SavedGameDetail.History.Nth(0).Length(assert.Equals(1))
+				a.PerformMove.SavedGameDetail.Hashes.Length(assert.Equals(2))
+				a.PerformMove.SavedGameDetail.Hashes.Nth(0).Uint64(assert.Equals(-5))
+				a.PerformMove.SavedGameDetail.Hashes.Nth(1).Uint64(assert.Equals(6))
+				return a.PerformMove.SavedGameDetail.History.Nth(1).Length(assert.Equals(2))
+			}(),
+		},
+		{
+			Desc: "undone moves are on the redo stack",
+			Command: &db.Lookup{
+				NewLookupTestPool(t, mockRow{values: []interface{}{
+					int64(2021), int32(0), int32(0), int16(0), int16(0), int32(1), int16(0), int32(0), true, int32(0), int64(0), "", "", int16(0), int32(0), int16(13), int32(0), "", int64(0), int32(0),
+					[]int16{}, []int16{}, []int16{}, []int16{}, []int32{},
+					[]int32{1, 2, 3, 3},
+					[]int16{0, 0, 0, 0},
+					[]int16{2, 1, 0, 0},
+					[]int16{0, 0, 0, 0},
+					[]int16{1, 1, 1, 1},
+					[]int16{0, 1, 2, 2},
+					[]int16{1, 1, 1, 1},
+					[]bool{false, true, true, true},
+					[]int64{5, 6, 7, 7},
+				}}),
+			},
+			Result: func() *assert.Assertion {
+				a := assert.New().NoError()
+				a.PerformMove.SavedGameDetail.History.Length(assert.Equals(1))
+				a.PerformMove.SavedGameDetail.Hashes.Length(assert.Equals(1))
+				a.PerformMove.SavedGameDetail.Redo.Length(assert.Equals(2))
+				a.PerformMove.SavedGameDetail.Redo.Nth(0).Length(assert.Equals(2))
+				return a.PerformMove.SavedGameDetail.Redo.Nth(1).Nth(0).OldPileIndex(assert.Equals(1))
+			}(),
+		},
+		{
+			Desc: "corrupt pile index",
+			Command: &db.Lookup{
+				NewLookupTestPool(t, mockRow{values: []interface{}{
+					int64(2021), int32(0), int32(0), int16(0), int16(0), int32(1), int16(0), int32(0), false, int32(0), int64(0), "", "", int16(0), int32(0), int16(13), int32(0), "", int64(0), int32(0),
+					[]int16{0}, []int16{1}, []int16{1}, []int16{1}, []int32{0},
+				}}),
+			},
+			Result: assert.New().Error.Category.Uint8(assert.Equals(poc.UnknownError)),
+		},
+		{
+			Desc: "game does not exist",
+			Command: &db.Lookup{
+				NewLookupTestPool(t, mockRow{err: pgx.ErrNoRows}),
+			},
+			Result: assert.New().Error.Category.Uint8(assert.Equals(poc.NotFoundError)),
+		},
+		{
+			Desc: "unknown query error",
+			Command: &db.Lookup{
+				NewLookupTestPool(t, mockRow{err: errors.New("check that this error is not reported as a missing game")}),
+			},
+			Result: assert.New().Error.Category.Uint8(assert.Equals(poc.UnknownError)),
+		},
+	}.Run(t)
+}
+
+func TestLookupGame(t *testing.T) {
+	logger.RegisterVerbose(t)
+	harness.LookupGame{
+		{
+			Desc: "hydrates board",
+			Command: &db.Lookup{
+				NewLookupTestPool(t, mockRow{values: []interface{}{
+					int64(2021), int32(0), int32(0), int16(0), int16(0), int32(1), int16(0), int32(0), false, int32(0), int64(0), "", "", int16(0), int32(0), int16(13), int32(0), "", int64(0), int32(0),
+					[]int16{1}, []int16{0}, []int16{1}, []int16{1}, []int32{int32(poc.FaceUp)},
+				}}),
+			},
+			Result: assert.New().NoError().
+				LookupGame.SavedGameDetail.Board.Piles.Nth(1).Length(assert.Equals(1)),
+		},
+		{
+			Desc: "game does not exist",
+			Command: &db.Lookup{
+				NewLookupTestPool(t, mockRow{err: pgx.ErrNoRows}),
+			},
+			Result: assert.New().Error.Category.Uint8(assert.Equals(poc.NotFoundError)),
+		},
+	}.Run(t)
+}
+
+func TestLookupPreviousGame(t *testing.T) {
+	logger.RegisterVerbose(t)
+	harness.StartGame{
+		{
+			Desc:    "no previous game",
+			Command: &db.Lookup{},
+			Result: assert.New().NoError().
+				StartGame.Previous.GameID(assert.Equals(0)),
+		},
+		{
+			Desc: "hydrates the previous game",
+			Command: &db.Lookup{
+				NewLookupTestPool(t, mockRow{values: []interface{}{
+					int64(2021), int32(-22), int32(0), int16(0), int16(1), int32(1), int16(1), int32(0), false, int32(0), int64(0), "", "", int16(0), int32(0), int16(13), int32(0), "", int64(0), int32(0),
+					[]int16{}, []int16{}, []int16{}, []int16{}, []int32{},
+				}}),
+			},
+			Input: poc.StartGame{SavedGameDetail: poc.SavedGameDetail{PreviousGameID: 2021}},
+			Result: func() *assert.Assertion {
+				a := assert.New().NoError()
+				a.StartGame.Previous.GameID(assert.Equals(2021))
+				return a.StartGame.Previous.Board.Score(assert.Equals(-22))
+			}(),
+		},
+		{
+			Desc: "previous game does not exist",
+			Command: &db.Lookup{
+				NewLookupTestPool(t, mockRow{err: pgx.ErrNoRows}),
+			},
+			Input:  poc.StartGame{SavedGameDetail: poc.SavedGameDetail{PreviousGameID: 2021}},
+			Result: assert.New().Error.Category.Uint8(assert.Equals(poc.NotFoundError)),
+		},
+	}.Run(t)
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+
 	"github.com/slcjordan/poc"
 	"github.com/slcjordan/poc/db/sqlc"
 	"github.com/slcjordan/poc/logger"
@@ -14,6 +17,7 @@ import (
 // Conn is a db conn.
 type Conn interface {
 	Release()
+	Begin(ctx context.Context) (pgx.Tx, error)
 	sqlc.DBTX
 }
 
@@ -27,7 +31,51 @@ type Save struct {
 	Pool Pool
 }
 
-// CallStartGame saves start.Result as a new game.
+// pileCards holds pile_card columns.
+type pileCards struct {
+	pileNums    []int16
+	pileIndexes []int16
+	suits       []int16
+	indexes     []int16
+	positions   []int32
+}
+
+func (p *pileCards) append(pileNum int, pile []poc.PositionedCard) {
+	for pileIndex, card := range pile {
+		p.pileNums = append(p.pileNums, int16(pileNum))
+		p.pileIndexes = append(p.pileIndexes, int16(pileIndex))
+		p.suits = append(p.suits, int16(card.Card.Suit))
+		p.indexes = append(p.indexes, int16(card.Card.Index))
+		p.positions = append(p.positions, int32(card.Position))
+	}
+}
+
+// slots holds slot and slot_cover columns.
+type slots struct {
+	pileNums      []int16
+	rows          []int16
+	columns       []int16
+	coverPileNums []int16
+	coveredBy     []int16
+}
+
+func (s *slots) append(board poc.Board) {
+	for _, slot := range board.Slots {
+		s.pileNums = append(s.pileNums, int16(slot.PileNum))
+		s.rows = append(s.rows, int16(slot.Row))
+		s.columns = append(s.columns, int16(slot.Column))
+	}
+	for _, cover := range board.Covers {
+		s.coverPileNums = append(s.coverPileNums, int16(cover.PileNum))
+		s.coveredBy = append(s.coveredBy, int16(cover.CoveredBy))
+	}
+}
+
+// uniqueViolation is the postgres error code for a broken unique constraint.
+const uniqueViolation = "23505"
+
+// CallStartGame saves start.Result as a new game. Only one game may carry on
+// the balance of a previous game.
 func (s *Save) CallStartGame(ctx context.Context, start poc.StartGame) (poc.StartGame, error) {
 	conn, err := s.Pool.Acquire(ctx)
 	if err != nil {
@@ -35,30 +83,48 @@ func (s *Save) CallStartGame(ctx context.Context, start poc.StartGame) (poc.Star
 		return start, poc.Error{Actual: errors.New("db unavailable"), Category: poc.UnavailableError}
 	}
 	defer conn.Release()
-	var pileNums []int16
-	var pileIndexes []int16
-	var suits []int16
-	var indexes []int16
-	var positions []int32
+	var cards pileCards
 
 	for pileNum, curr := range start.SavedGameDetail.Board.Piles {
-		for pileIndex, card := range curr {
-			pileNums = append(pileNums, int16(pileNum))
-			pileIndexes = append(pileIndexes, int16(pileIndex))
-			suits = append(suits, int16(card.Card.Suit))
-			indexes = append(indexes, int16(card.Card.Index))
-			positions = append(positions, int32(card.Position))
-		}
+		cards.append(pileNum, curr)
 	}
+	var layout slots
+	layout.append(start.SavedGameDetail.Board)
 	gameID, err := sqlc.New(conn).SaveStartGame(ctx, sqlc.SaveStartGameParams{
 		Score:               start.SavedGameDetail.Board.Score,
-		PileNums:            pileNums,
-		PileIndexes:         pileIndexes,
-		Suits:               suits,
-		Indexes:             indexes,
-		Positions:           positions,
+		PileNums:            cards.pileNums,
+		PileIndexes:         cards.pileIndexes,
+		Suits:               cards.suits,
+		Indexes:             cards.indexes,
+		Positions:           cards.positions,
 		MaxTimesThroughDeck: start.SavedGameDetail.Variant.MaxTimesThroughDeck,
+		EmptyColumnFill:     int16(start.SavedGameDetail.Variant.EmptyColumnFill),
+		Scoring:             int16(start.SavedGameDetail.Variant.Scoring),
+		DrawCount:           start.SavedGameDetail.Variant.DrawCount,
+		Status:              int16(start.SavedGameDetail.Status),
+		HintPenalty:         start.SavedGameDetail.Variant.HintPenalty,
+		AllowUndo:           start.SavedGameDetail.Variant.AllowUndo,
+		UndoPenalty:         start.SavedGameDetail.Variant.UndoPenalty,
+		Seed:                start.SavedGameDetail.Seed,
+		Salt:                start.SavedGameDetail.Salt,
+		Commitment:          start.SavedGameDetail.Commitment,
+		Family:              int16(start.SavedGameDetail.Variant.Family),
+		SuitCount:           start.SavedGameDetail.Variant.Suits,
+		Jokers:              start.SavedGameDetail.Variant.Jokers,
+		PileCount:           int16(len(start.SavedGameDetail.Board.Piles)),
+		SlotPileNums:        layout.pileNums,
+		SlotRows:            layout.rows,
+		SlotColumns:         layout.columns,
+		CoverPileNums:       layout.coverPileNums,
+		CoveredBy:           layout.coveredBy,
+		Ruleset:             start.SavedGameDetail.Variant.Name,
+		PreviousGameID:      start.SavedGameDetail.PreviousGameID,
 	})
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		logger.Infof(ctx, "could not save game: %s", err)
+		return start, poc.Error{Actual: errors.New("previous game was already carried on"), Category: poc.ConflictError}
+	}
 	if err != nil {
 		logger.Errorf(ctx, "could not save game: %s", err)
 		return start, poc.Error{Actual: errors.New("could not save game"), Category: poc.UnknownError}
@@ -67,40 +133,305 @@ func (s *Save) CallStartGame(ctx context.Context, start poc.StartGame) (poc.Star
 	return start, nil
 }
 
-// CallPerformMove updates start.Result as an existing game and expects move.Input.GameID to be set.
+// CallPerformMove records move.Next under the next move number and rewrites
+// the affected piles and the score from move.SavedGameDetail in a single
+// transaction. It expects move.SavedGameDetail.GameID to be set.
 func (s *Save) CallPerformMove(ctx context.Context, move poc.PerformMove) (poc.PerformMove, error) {
 	conn, err := s.Pool.Acquire(ctx)
 	if err != nil {
 		logger.Infof(ctx, "could not acquire connection: %s", err)
 		return move, poc.Error{Actual: errors.New("db unavailable"), Category: poc.UnavailableError}
 	}
-	oldPileNums := make([]int, len(move.Next))
-	oldPileIndexes := make([]int, len(move.Next))
-	oldPilePositions := make([]uint64, len(move.Next))
-	newPileNums := make([]int, len(move.Next))
-	newPileIndexes := make([]int, len(move.Next))
-	newPilePositions := make([]uint64, len(move.Next))
-
-	for i, curr := range move.Next {
-		oldPileNums[i] = curr.OldPileNum
-		oldPileIndexes[i] = curr.OldPileIndex
-		oldPilePositions[i] = uint64(curr.OldPilePosition)
-		newPileNums[i] = curr.NewPileNum
-		newPileIndexes[i] = curr.NewPileIndex
-		newPilePositions[i] = uint64(curr.NewPilePosition)
-	}
-	_, err = sqlc.New(conn).SavePerformMove(ctx, sqlc.SavePerformMoveParams{
-		GameID:           move.SavedGameDetail.GameID,
-		OldPileNums:      oldPileNums,
-		OldPileIndexes:   oldPileIndexes,
-		OldPilePositions: oldPilePositions,
-		NewPileNums:      newPileNums,
-		NewPileIndexes:   newPileIndexes,
-		NewPilePositions: newPilePositions,
-	})
+	defer conn.Release()
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "could not begin transaction: %s", err)
+		return move, poc.Error{Actual: errors.New("could not save game"), Category: poc.UnknownError}
+	}
+	defer tx.Rollback(ctx)
+
+	err = saveMoves(ctx, sqlc.New(conn).WithTx(tx), move.SavedGameDetail, move.Next)
+	if errors.Is(err, errGameMoved) {
+		logger.Infof(ctx, "could not save game %d: %s", move.SavedGameDetail.GameID, err)
+		return move, poc.Error{Actual: err, Category: poc.ConflictError}
+	}
 	if err != nil {
 		logger.Errorf(ctx, "could not save game %d: %s", move.SavedGameDetail.GameID, err)
 		return move, poc.Error{Actual: errors.New("could not save game"), Category: poc.UnknownError}
 	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "could not commit game %d: %s", move.SavedGameDetail.GameID, err)
+		return move, poc.Error{Actual: errors.New("could not save game"), Category: poc.UnknownError}
+	}
 	return move, nil
 }
+
+// errGameMoved means another move was saved after the game was looked up.
+var errGameMoved = errors.New("game was changed by another move")
+
+// saveMoves records each move group under its own move number and rewrites
+// every pile they touched. The groups are the last of game.History, so each
+// one is saved with its hash from game.Hashes. The game is locked first, and
+// a group whose move number has already been taken fails with errGameMoved.
+func saveMoves(ctx context.Context, q *sqlc.Queries, game poc.SavedGameDetail, groups ...[]poc.Move) error {
+	affected := make(map[int]bool)
+	err := q.LockGame(ctx, game.GameID)
+	if err != nil {
+		return err
+	}
+
+	for g, moves := range groups {
+		oldPileNums := make([]int16, len(moves))
+		oldPileIndexes := make([]int16, len(moves))
+		oldPilePositions := make([]int16, len(moves))
+		newPileNums := make([]int16, len(moves))
+		newPileIndexes := make([]int16, len(moves))
+		newPilePositions := make([]int16, len(moves))
+
+		for i, curr := range moves {
+			oldPileNums[i] = int16(curr.OldPileNum)
+			oldPileIndexes[i] = int16(curr.OldPileIndex)
+			oldPilePositions[i] = int16(curr.OldPilePosition)
+			newPileNums[i] = int16(curr.NewPileNum)
+			newPileIndexes[i] = int16(curr.NewPileIndex)
+			newPilePositions[i] = int16(curr.NewPilePosition)
+			affected[curr.OldPileNum] = true
+			affected[curr.NewPileNum] = true
+		}
+		before := len(game.History) - len(groups) + g // moves made before this group
+		saved, err := q.SavePerformMove(ctx, sqlc.SavePerformMoveParams{
+			GameID:           game.GameID,
+			OldPileNums:      oldPileNums,
+			OldPileIndexes:   oldPileIndexes,
+			OldPilePositions: oldPilePositions,
+			NewPileNums:      newPileNums,
+			NewPileIndexes:   newPileIndexes,
+			NewPilePositions: newPilePositions,
+			BoardHash:        boardHash(game, before),
+			MoveNumber:       int32(before + 1),
+		})
+		if err != nil {
+			return err
+		}
+		if len(saved) < 1 {
+			return errGameMoved
+		}
+	}
+	return savePiles(ctx, q, game, affected)
+}
+
+// boardHash is the hash of the position after History group i, or 0 when it
+// isn't known.
+func boardHash(game poc.SavedGameDetail, i int) int64 {
+	if i < 0 || i >= len(game.Hashes) {
+		return 0
+	}
+	return int64(game.Hashes[i])
+}
+
+// savePiles rewrites the affected piles and saves the score and status.
+func savePiles(ctx context.Context, q *sqlc.Queries, game poc.SavedGameDetail, affected map[int]bool) error {
+	var affectedPileNums []int16
+	var cards pileCards
+	for pileNum, curr := range game.Board.Piles {
+		if !affected[pileNum] {
+			continue
+		}
+		affectedPileNums = append(affectedPileNums, int16(pileNum))
+		cards.append(pileNum, curr)
+	}
+	err := q.SavePileCards(ctx, sqlc.SavePileCardsParams{
+		GameID:           game.GameID,
+		AffectedPileNums: affectedPileNums,
+		PileNums:         cards.pileNums,
+		PileIndexes:      cards.pileIndexes,
+		Suits:            cards.suits,
+		Indexes:          cards.indexes,
+		Positions:        cards.positions,
+	})
+	if err != nil {
+		return err
+	}
+	return q.UpdateGame(ctx, sqlc.UpdateGameParams{
+		Score:  game.Board.Score,
+		Status: int16(game.Status),
+		GameID: game.GameID,
+	})
+}
+
+// CallAutoComplete records each of game.Moves under its own move number in a
+// single transaction. It expects game.SavedGameDetail.GameID to be set.
+func (s *Save) CallAutoComplete(ctx context.Context, game poc.AutoComplete) (poc.AutoComplete, error) {
+	conn, err := s.Pool.Acquire(ctx)
+	if err != nil {
+		logger.Infof(ctx, "could not acquire connection: %s", err)
+		return game, poc.Error{Actual: errors.New("db unavailable"), Category: poc.UnavailableError}
+	}
+	defer conn.Release()
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "could not begin transaction: %s", err)
+		return game, poc.Error{Actual: errors.New("could not save game"), Category: poc.UnknownError}
+	}
+	defer tx.Rollback(ctx)
+
+	err = saveMoves(ctx, sqlc.New(conn).WithTx(tx), game.SavedGameDetail, game.Moves...)
+	if errors.Is(err, errGameMoved) {
+		logger.Infof(ctx, "could not save game %d: %s", game.SavedGameDetail.GameID, err)
+		return game, poc.Error{Actual: err, Category: poc.ConflictError}
+	}
+	if err != nil {
+		logger.Errorf(ctx, "could not save game %d: %s", game.SavedGameDetail.GameID, err)
+		return game, poc.Error{Actual: errors.New("could not save game"), Category: poc.UnknownError}
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "could not commit game %d: %s", game.SavedGameDetail.GameID, err)
+		return game, poc.Error{Actual: errors.New("could not save game"), Category: poc.UnknownError}
+	}
+	return game, nil
+}
+
+// affectedPiles lists the piles touched by a group of moves.
+func affectedPiles(moves []poc.Move) map[int]bool {
+	affected := make(map[int]bool)
+	for _, curr := range moves {
+		affected[curr.OldPileNum] = true
+		affected[curr.NewPileNum] = true
+	}
+	return affected
+}
+
+// CallUndoMove marks the last move as undone and rewrites the piles it touched in a
+// single transaction. It expects game.SavedGameDetail.GameID to be set.
+func (s *Save) CallUndoMove(ctx context.Context, game poc.UndoMove) (poc.UndoMove, error) {
+	redo := game.SavedGameDetail.Redo
+	if len(redo) < 1 {
+		return game, poc.Error{Actual: errors.New("no move to undo"), Category: poc.SemanticError}
+	}
+	conn, err := s.Pool.Acquire(ctx)
+	if err != nil {
+		logger.Infof(ctx, "could not acquire connection: %s", err)
+		return game, poc.Error{Actual: errors.New("db unavailable"), Category: poc.UnavailableError}
+	}
+	defer conn.Release()
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "could not begin transaction: %s", err)
+		return game, poc.Error{Actual: errors.New("could not save game"), Category: poc.UnknownError}
+	}
+	defer tx.Rollback(ctx)
+
+	q := sqlc.New(conn).WithTx(tx)
+	err = q.SaveUndoMove(ctx, game.SavedGameDetail.GameID)
+	if err == nil {
+		err = savePiles(ctx, q, game.SavedGameDetail, affectedPiles(redo[len(redo)-1]))
+	}
+	if err != nil {
+		logger.Errorf(ctx, "could not save game %d: %s", game.SavedGameDetail.GameID, err)
+		return game, poc.Error{Actual: errors.New("could not save game"), Category: poc.UnknownError}
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "could not commit game %d: %s", game.SavedGameDetail.GameID, err)
+		return game, poc.Error{Actual: errors.New("could not save game"), Category: poc.UnknownError}
+	}
+	return game, nil
+}
+
+// CallRedoMove marks the last undone move as done again and rewrites the piles it touched in a
+// single transaction. It expects game.SavedGameDetail.GameID to be set.
+func (s *Save) CallRedoMove(ctx context.Context, game poc.RedoMove) (poc.RedoMove, error) {
+	history := game.SavedGameDetail.History
+	if len(history) < 1 {
+		return game, poc.Error{Actual: errors.New("no move to redo"), Category: poc.SemanticError}
+	}
+	conn, err := s.Pool.Acquire(ctx)
+	if err != nil {
+		logger.Infof(ctx, "could not acquire connection: %s", err)
+		return game, poc.Error{Actual: errors.New("db unavailable"), Category: poc.UnavailableError}
+	}
+	defer conn.Release()
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "could not begin transaction: %s", err)
+		return game, poc.Error{Actual: errors.New("could not save game"), Category: poc.UnknownError}
+	}
+	defer tx.Rollback(ctx)
+
+	q := sqlc.New(conn).WithTx(tx)
+	err = q.SaveRedoMove(ctx, game.SavedGameDetail.GameID)
+	if err == nil {
+		err = savePiles(ctx, q, game.SavedGameDetail, affectedPiles(history[len(history)-1]))
+	}
+	if err != nil {
+		logger.Errorf(ctx, "could not save game %d: %s", game.SavedGameDetail.GameID, err)
+		return game, poc.Error{Actual: errors.New("could not save game"), Category: poc.UnknownError}
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "could not commit game %d: %s", game.SavedGameDetail.GameID, err)
+		return game, poc.Error{Actual: errors.New("could not save game"), Category: poc.UnknownError}
+	}
+	return game, nil
+}
+
+// CallHint records that a hint was given at the current move number and
+// saves the score in a single transaction. It expects
+// game.SavedGameDetail.GameID to be set.
+func (s *Save) CallHint(ctx context.Context, game poc.Hint) (poc.Hint, error) {
+	conn, err := s.Pool.Acquire(ctx)
+	if err != nil {
+		logger.Infof(ctx, "could not acquire connection: %s", err)
+		return game, poc.Error{Actual: errors.New("db unavailable"), Category: poc.UnavailableError}
+	}
+	defer conn.Release()
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "could not begin transaction: %s", err)
+		return game, poc.Error{Actual: errors.New("could not save game"), Category: poc.UnknownError}
+	}
+	defer tx.Rollback(ctx)
+
+	q := sqlc.New(conn).WithTx(tx)
+	err = q.SaveHint(ctx, game.SavedGameDetail.GameID)
+	if err == nil {
+		err = q.UpdateGame(ctx, sqlc.UpdateGameParams{
+			Score:  game.SavedGameDetail.Board.Score,
+			Status: int16(game.SavedGameDetail.Status),
+			GameID: game.SavedGameDetail.GameID,
+		})
+	}
+	if err != nil {
+		logger.Errorf(ctx, "could not save game %d: %s", game.SavedGameDetail.GameID, err)
+		return game, poc.Error{Actual: errors.New("could not save game"), Category: poc.UnknownError}
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "could not commit game %d: %s", game.SavedGameDetail.GameID, err)
+		return game, poc.Error{Actual: errors.New("could not save game"), Category: poc.UnknownError}
+	}
+	return game, nil
+}
+
+// CallResignGame records the final status of a resigned game. It expects
+// game.SavedGameDetail.GameID to be set.
+func (s *Save) CallResignGame(ctx context.Context, game poc.ResignGame) (poc.ResignGame, error) {
+	conn, err := s.Pool.Acquire(ctx)
+	if err != nil {
+		logger.Infof(ctx, "could not acquire connection: %s", err)
+		return game, poc.Error{Actual: errors.New("db unavailable"), Category: poc.UnavailableError}
+	}
+	defer conn.Release()
+	err = sqlc.New(conn).UpdateGame(ctx, sqlc.UpdateGameParams{
+		Score:  game.SavedGameDetail.Board.Score,
+		Status: int16(game.SavedGameDetail.Status),
+		GameID: game.SavedGameDetail.GameID,
+	})
+	if err != nil {
+		logger.Errorf(ctx, "could not save game %d: %s", game.SavedGameDetail.GameID, err)
+		return game, poc.Error{Actual: errors.New("could not save game"), Category: poc.UnknownError}
+	}
+	return game, nil
+}
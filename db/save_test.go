@@ -2,9 +2,12 @@ package db_test
 
 import (
 	"errors"
+	"reflect"
 	"testing"
 
 	gomock "github.com/golang/mock/gomock"
+	"github.com/jackc/pgconn"
+	"github.com/pashagolub/pgxmock"
 
 	"github.com/slcjordan/poc"
 	"github.com/slcjordan/poc/db"
@@ -15,10 +18,14 @@ import (
 )
 
 type mockRow struct {
-	err error
+	err    error
+	values []interface{}
 }
 
 func (m mockRow) Scan(dest ...interface{}) error {
+	for i, val := range m.values {
+		reflect.ValueOf(dest[i]).Elem().Set(reflect.ValueOf(val))
+	}
 	return m.err
 }
 
@@ -27,8 +34,9 @@ func NewSaveTestPool(t *testing.T, err error) *mocks.MockPool {
 	pool := mocks.NewMockPool(ctrl)
 	conn := mocks.NewMockConn(ctrl)
 	conn.EXPECT().QueryRow(
-		gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
-	).Return(mockRow{err})
+		gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+	).Return(mockRow{err: err})
 	conn.EXPECT().Release()
 	pool.
 		EXPECT().
@@ -54,5 +62,307 @@ func TestSave(t *testing.T) {
 			},
 			Result: assert.New().Error.Category.Uint8(assert.Equals(poc.UnknownError)),
 		},
+		{
+			Desc: "previous game already carried on",
+			Command: &db.Save{
+				NewSaveTestPool(t, &pgconn.PgError{Code: "23505", ConstraintName: "game_previous_game_id_key"}),
+			},
+			Result: assert.New().Error.Category.Uint8(assert.Equals(poc.ConflictError)),
+		},
+	}.Run(t)
+}
+
+type pgxmockConn struct {
+	pgxmock.PgxConnIface
+}
+
+func (p pgxmockConn) Release() {}
+
+func NewSavePerformMoveTestPool(t *testing.T, expect func(pgxmock.PgxConnIface)) *mocks.MockPool {
+	ctrl := gomock.NewController(t)
+	pool := mocks.NewMockPool(ctrl)
+	conn, err := pgxmock.NewConn()
+	if err != nil {
+		t.Fatalf("could not create mock conn: %s", err)
+	}
+	expect(conn)
+	t.Cleanup(func() {
+		err := conn.ExpectationsWereMet()
+		if err != nil {
+			t.Errorf("unmet db expectations: %s", err)
+		}
+	})
+	pool.
+		EXPECT().
+		Acquire(gomock.Any()).
+		Return(pgxmockConn{conn}, nil)
+	return pool
+}
+
+func TestSavePerformMove(t *testing.T) {
+	logger.RegisterVerbose(t)
+	var game poc.SavedGameDetail
+	game.Board.Piles = make([][]poc.PositionedCard, 13)
+	game.GameID = 2021
+	game.Board.Score = 5
+	game.Board.Piles[1] = []poc.PositionedCard{
+		{Position: poc.FaceUp, Card: poc.Card{Suit: poc.Hearts, Index: poc.Ace}},
+	}
+	next := []poc.Move{
+		{OldPileNum: 0, OldPileIndex: 3, NewPileNum: 1, NewPileIndex: 0, NewPilePosition: poc.FaceUp},
+	}
+	game.History = [][]poc.Move{next}
+	game.Hashes = []poc.Hash{42}
+	harness.PerformMove{
+		{
+			Desc: "sanity check",
+			Command: &db.Save{
+				NewSavePerformMoveTestPool(t, func(conn pgxmock.PgxConnIface) {
+					conn.ExpectBegin()
+					conn.ExpectExec("SELECT id FROM game").
+						WithArgs(int64(2021)).
+						WillReturnResult(pgxmock.NewResult("SELECT", 1))
+					conn.ExpectQuery("INSERT INTO history").
+						WithArgs(int64(2021), []int16{0}, []int16{3}, []int16{0}, []int16{1}, []int16{0}, []int16{int16(poc.FaceUp)}, int64(42), int32(1)).
+						WillReturnRows(pgxmock.NewRows([]string{"game_id", "move_id"}).AddRow(int64(2021), int64(1)))
+					conn.ExpectExec("INSERT INTO pile_card").
+						WithArgs(int64(2021), []int16{0, 1}, []int16{1}, []int16{0}, []int16{int16(poc.Hearts)}, []int16{int16(poc.Ace)}, []int32{int32(poc.FaceUp)}).
+						WillReturnResult(pgxmock.NewResult("INSERT", 1))
+					conn.ExpectExec("UPDATE game").
+						WithArgs(int32(5), int16(poc.InProgress), int64(2021)).
+						WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+					conn.ExpectCommit()
+				}),
+			},
+			Input:  poc.PerformMove{Next: next, SavedGameDetail: game},
+			Result: assert.New().NoError(),
+		},
+		{
+			Desc: "failed move insert rolls back",
+			Command: &db.Save{
+				NewSavePerformMoveTestPool(t, func(conn pgxmock.PgxConnIface) {
+					conn.ExpectBegin()
+					conn.ExpectExec("SELECT id FROM game").
+						WithArgs(int64(2021)).
+						WillReturnResult(pgxmock.NewResult("SELECT", 1))
+					conn.ExpectQuery("INSERT INTO history").
+						WillReturnError(errors.New("check that this error correctly causes the transaction to roll back"))
+					conn.ExpectRollback()
+				}),
+			},
+			Input:  poc.PerformMove{Next: next, SavedGameDetail: game},
+			Result: assert.New().Error.Category.Uint8(assert.Equals(poc.UnknownError)),
+		},
+		{
+			Desc: "a move saved since the lookup is a conflict",
+			Command: &db.Save{
+				NewSavePerformMoveTestPool(t, func(conn pgxmock.PgxConnIface) {
+					conn.ExpectBegin()
+					conn.ExpectExec("SELECT id FROM game").
+						WithArgs(int64(2021)).
+						WillReturnResult(pgxmock.NewResult("SELECT", 1))
+					conn.ExpectQuery("INSERT INTO history").
+						WithArgs(int64(2021), []int16{0}, []int16{3}, []int16{0}, []int16{1}, []int16{0}, []int16{int16(poc.FaceUp)}, int64(42), int32(1)).
+						WillReturnRows(pgxmock.NewRows([]string{"game_id", "move_id"}))
+					conn.ExpectRollback()
+				}),
+			},
+			Input:  poc.PerformMove{Next: next, SavedGameDetail: game},
+			Result: assert.New().Error.Category.Uint8(assert.Equals(poc.ConflictError)),
+		},
+	}.Run(t)
+}
+
+func TestSaveResignGame(t *testing.T) {
+	logger.RegisterVerbose(t)
+	var game poc.SavedGameDetail
+	game.Board.Piles = make([][]poc.PositionedCard, 13)
+	game.GameID = 2021
+	game.Board.Score = 5
+	game.Status = poc.Resigned
+	harness.ResignGame{
+		{
+			Desc: "sanity check",
+			Command: &db.Save{
+				NewSavePerformMoveTestPool(t, func(conn pgxmock.PgxConnIface) {
+					conn.ExpectExec("UPDATE game").
+						WithArgs(int32(5), int16(poc.Resigned), int64(2021)).
+						WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+				}),
+			},
+			Input:  poc.ResignGame{SavedGameDetail: game},
+			Result: assert.New().NoError(),
+		},
+	}.Run(t)
+}
+
+func TestSaveAutoComplete(t *testing.T) {
+	logger.RegisterVerbose(t)
+	var game poc.SavedGameDetail
+	game.Board.Piles = make([][]poc.PositionedCard, 13)
+	game.GameID = 2021
+	game.Board.Score = 20
+	game.Status = poc.Won
+	game.Board.Piles[9] = []poc.PositionedCard{
+		{Position: poc.FaceUp, Card: poc.Card{Suit: poc.Hearts, Index: poc.Ace}},
+		{Position: poc.FaceUp, Card: poc.Card{Suit: poc.Hearts, Index: poc.Two}},
+	}
+	moves := [][]poc.Move{
+		{{OldPileNum: 2, OldPileIndex: 1, OldPilePosition: poc.FaceUp, NewPileNum: 9, NewPileIndex: 0, NewPilePosition: poc.FaceUp}},
+		{{OldPileNum: 2, OldPileIndex: 0, OldPilePosition: poc.FaceUp, NewPileNum: 9, NewPileIndex: 1, NewPilePosition: poc.FaceUp}},
+	}
+	game.History = moves
+	game.Hashes = []poc.Hash{7, 8}
+	harness.AutoComplete{
+		{
+			Desc: "each move gets its own history entry",
+			Command: &db.Save{
+				NewSavePerformMoveTestPool(t, func(conn pgxmock.PgxConnIface) {
+					conn.ExpectBegin()
+					conn.ExpectExec("SELECT id FROM game").
+						WithArgs(int64(2021)).
+						WillReturnResult(pgxmock.NewResult("SELECT", 1))
+					conn.ExpectQuery("INSERT INTO history").
+						WithArgs(int64(2021), []int16{2}, []int16{1}, []int16{int16(poc.FaceUp)}, []int16{9}, []int16{0}, []int16{int16(poc.FaceUp)}, int64(7), int32(1)).
+						WillReturnRows(pgxmock.NewRows([]string{"game_id", "move_id"}).AddRow(int64(2021), int64(1)))
+					conn.ExpectQuery("INSERT INTO history").
+						WithArgs(int64(2021), []int16{2}, []int16{0}, []int16{int16(poc.FaceUp)}, []int16{9}, []int16{1}, []int16{int16(poc.FaceUp)}, int64(8), int32(2)).
+						WillReturnRows(pgxmock.NewRows([]string{"game_id", "move_id"}).AddRow(int64(2021), int64(2)))
+					conn.ExpectExec("INSERT INTO pile_card").
+						WithArgs(int64(2021), []int16{2, 9}, []int16{9, 9}, []int16{0, 1}, []int16{int16(poc.Hearts), int16(poc.Hearts)}, []int16{int16(poc.Ace), int16(poc.Two)}, []int32{int32(poc.FaceUp), int32(poc.FaceUp)}).
+						WillReturnResult(pgxmock.NewResult("INSERT", 2))
+					conn.ExpectExec("UPDATE game").
+						WithArgs(int32(20), int16(poc.Won), int64(2021)).
+						WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+					conn.ExpectCommit()
+				}),
+			},
+			Input:  poc.AutoComplete{Moves: moves, SavedGameDetail: game},
+			Result: assert.New().NoError(),
+		},
+	}.Run(t)
+}
+
+func TestSaveHint(t *testing.T) {
+	logger.RegisterVerbose(t)
+	var game poc.SavedGameDetail
+	game.Board.Piles = make([][]poc.PositionedCard, 13)
+	game.GameID = 2021
+	game.Board.Score = 5
+	harness.Hint{
+		{
+			Desc: "records the hint with the score",
+			Command: &db.Save{
+				NewSavePerformMoveTestPool(t, func(conn pgxmock.PgxConnIface) {
+					conn.ExpectBegin()
+					conn.ExpectExec("INSERT INTO hint").
+						WithArgs(int64(2021)).
+						WillReturnResult(pgxmock.NewResult("INSERT", 1))
+					conn.ExpectExec("UPDATE game").
+						WithArgs(int32(5), int16(poc.InProgress), int64(2021)).
+						WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+					conn.ExpectCommit()
+				}),
+			},
+			Input:  poc.Hint{SavedGameDetail: game},
+			Result: assert.New().NoError(),
+		},
+	}.Run(t)
+}
+
+func TestSaveUndoMove(t *testing.T) {
+	logger.RegisterVerbose(t)
+	var game poc.SavedGameDetail
+	game.Board.Piles = make([][]poc.PositionedCard, 13)
+	game.GameID = 2021
+	game.Board.Score = 5
+	game.Board.Piles[0] = []poc.PositionedCard{
+		{Card: poc.Card{Suit: poc.Hearts, Index: poc.Ace}},
+	}
+	game.Redo = [][]poc.Move{
+		{{OldPileNum: 0, OldPileIndex: 0, NewPileNum: 1, NewPileIndex: 0, NewPilePosition: poc.FaceUp}},
+	}
+	harness.UndoMove{
+		{
+			Desc: "marks the move undone and rewrites its piles",
+			Command: &db.Save{
+				NewSavePerformMoveTestPool(t, func(conn pgxmock.PgxConnIface) {
+					conn.ExpectBegin()
+					conn.ExpectExec("UPDATE history SET undone = true").
+						WithArgs(int64(2021)).
+						WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+					conn.ExpectExec("INSERT INTO pile_card").
+						WithArgs(int64(2021), []int16{0, 1}, []int16{0}, []int16{0}, []int16{int16(poc.Hearts)}, []int16{int16(poc.Ace)}, []int32{0}).
+						WillReturnResult(pgxmock.NewResult("INSERT", 1))
+					conn.ExpectExec("UPDATE game").
+						WithArgs(int32(5), int16(poc.InProgress), int64(2021)).
+						WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+					conn.ExpectCommit()
+				}),
+			},
+			Input:  poc.UndoMove{SavedGameDetail: game},
+			Result: assert.New().NoError(),
+		},
+		{
+			Desc:    "nothing to undo",
+			Command: &db.Save{mocks.NewMockPool(gomock.NewController(t))},
+			Input:   poc.UndoMove{SavedGameDetail: poc.SavedGameDetail{GameID: 2021}},
+			Result:  assert.New().Error.Category.Uint8(assert.Equals(poc.SemanticError)),
+		},
+	}.Run(t)
+}
+
+func TestSaveRedoMove(t *testing.T) {
+	logger.RegisterVerbose(t)
+	var game poc.SavedGameDetail
+	game.Board.Piles = make([][]poc.PositionedCard, 13)
+	game.GameID = 2021
+	game.Board.Score = 5
+	game.Board.Piles[1] = []poc.PositionedCard{
+		{Position: poc.FaceUp, Card: poc.Card{Suit: poc.Hearts, Index: poc.Ace}},
+	}
+	game.History = [][]poc.Move{
+		{{OldPileNum: 0, OldPileIndex: 0, NewPileNum: 1, NewPileIndex: 0, NewPilePosition: poc.FaceUp}},
+	}
+	harness.RedoMove{
+		{
+			Desc: "marks the move done and rewrites its piles",
+			Command: &db.Save{
+				NewSavePerformMoveTestPool(t, func(conn pgxmock.PgxConnIface) {
+					conn.ExpectBegin()
+					conn.ExpectExec("UPDATE history SET undone = false").
+						WithArgs(int64(2021)).
+						WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+					conn.ExpectExec("INSERT INTO pile_card").
+						WithArgs(int64(2021), []int16{0, 1}, []int16{1}, []int16{0}, []int16{int16(poc.Hearts)}, []int16{int16(poc.Ace)}, []int32{int32(poc.FaceUp)}).
+						WillReturnResult(pgxmock.NewResult("INSERT", 1))
+					conn.ExpectExec("UPDATE game").
+						WithArgs(int32(5), int16(poc.InProgress), int64(2021)).
+						WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+					conn.ExpectCommit()
+				}),
+			},
+			Input:  poc.RedoMove{SavedGameDetail: game},
+			Result: assert.New().NoError(),
+		},
+		{
+			Desc: "failed redo rolls back",
+			Command: &db.Save{
+				NewSavePerformMoveTestPool(t, func(conn pgxmock.PgxConnIface) {
+					conn.ExpectBegin()
+					conn.ExpectExec("UPDATE history SET undone = false").
+						WillReturnError(errors.New("check that this error correctly causes the transaction to roll back"))
+					conn.ExpectRollback()
+				}),
+			},
+			Input:  poc.RedoMove{SavedGameDetail: game},
+			Result: assert.New().Error.Category.Uint8(assert.Equals(poc.UnknownError)),
+		},
+		{
+			Desc:    "nothing to redo",
+			Command: &db.Save{mocks.NewMockPool(gomock.NewController(t))},
+			Input:   poc.RedoMove{SavedGameDetail: poc.SavedGameDetail{GameID: 2021}},
+			Result:  assert.New().Error.Category.Uint8(assert.Equals(poc.SemanticError)),
+		},
 	}.Run(t)
 }
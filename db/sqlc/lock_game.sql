@@ -0,0 +1,4 @@
+-- Lock a game until the end of the transaction so moves are saved one at a time.
+-- name: LockGame :exec
+
+SELECT id FROM game WHERE id = @game_id FOR UPDATE;
@@ -1,17 +1,57 @@
 -- Lookup a game.
 -- name: LookupGameDetail :one
 
-SELECT game.id, score, max_times_through_deck, array_agg(piles) piles, array_agg(hist_moves) history
+SELECT game.id, score, max_times_through_deck, empty_column_fill, scoring,
+  draw_count, status, hint_penalty, allow_undo, undo_penalty, seed, salt,
+  commitment, family, suit_count, pile_count, jokers, ruleset,
+  COALESCE(previous_game_id, 0)::bigint AS previous_game_id,
+  (SELECT count(*) FROM hint WHERE hint.game_id = game.id)::integer AS hints_used,
+  p.pile_nums::smallint[] AS pile_nums,
+  p.pile_indexes::smallint[] AS pile_indexes,
+  p.suits::smallint[] AS suits,
+  p.indexes::smallint[] AS indexes,
+  p.positions::integer[] AS positions,
+  m.move_numbers::integer[] AS move_numbers,
+  m.old_pile_nums::smallint[] AS old_pile_nums,
+  m.old_pile_indexes::smallint[] AS old_pile_indexes,
+  m.old_pile_positions::smallint[] AS old_pile_positions,
+  m.new_pile_nums::smallint[] AS new_pile_nums,
+  m.new_pile_indexes::smallint[] AS new_pile_indexes,
+  m.new_pile_positions::smallint[] AS new_pile_positions,
+  m.undone::boolean[] AS undone,
+  m.board_hashes::bigint[] AS board_hashes,
+  s.slot_pile_nums::smallint[] AS slot_pile_nums,
+  s.slot_rows::smallint[] AS slot_rows,
+  s.slot_columns::smallint[] AS slot_columns,
+  c.cover_pile_nums::smallint[] AS cover_pile_nums,
+  c.covered_by::smallint[] AS covered_by
 FROM game JOIN LATERAL (
-  SELECT array_agg((suit, index, position) ORDER BY pile_index) piles
+  SELECT COALESCE(array_agg(pile_num ORDER BY pile_num, pile_index), '{}') pile_nums,
+    COALESCE(array_agg(pile_index ORDER BY pile_num, pile_index), '{}') pile_indexes,
+    COALESCE(array_agg(suit ORDER BY pile_num, pile_index), '{}') suits,
+    COALESCE(array_agg(index ORDER BY pile_num, pile_index), '{}') indexes,
+    COALESCE(array_agg(position ORDER BY pile_num, pile_index), '{}') positions
   FROM pile_card WHERE game_id = game.id
-  GROUP BY pile_num ORDER BY pile_num
-) p ON TRUE JOIN LATERAL(
-  select array_agg(
-    (old_pile_num, old_pile_index, old_pile_position, new_pile_num, new_pile_index, new_pile_position)
-    ORDER BY old_pile_num, old_pile_index) hist_moves
+) p ON TRUE JOIN LATERAL (
+  SELECT COALESCE(array_agg(move_number ORDER BY move_number, history.id), '{}') move_numbers,
+    COALESCE(array_agg(old_pile_num ORDER BY move_number, history.id), '{}') old_pile_nums,
+    COALESCE(array_agg(old_pile_index ORDER BY move_number, history.id), '{}') old_pile_indexes,
+    COALESCE(array_agg(old_pile_position ORDER BY move_number, history.id), '{}') old_pile_positions,
+    COALESCE(array_agg(new_pile_num ORDER BY move_number, history.id), '{}') new_pile_nums,
+    COALESCE(array_agg(new_pile_index ORDER BY move_number, history.id), '{}') new_pile_indexes,
+    COALESCE(array_agg(new_pile_position ORDER BY move_number, history.id), '{}') new_pile_positions,
+    COALESCE(array_agg(undone ORDER BY move_number, history.id), '{}') undone,
+    COALESCE(array_agg(board_hash ORDER BY move_number, history.id), '{}') board_hashes
   FROM history
   JOIN move ON move.id = history.move_id
   WHERE history.game_id = game.id
-  GROUP BY move_number
-) m ON TRUE WHERE game.id = @game_id GROUP BY game.id;
+) m ON TRUE JOIN LATERAL (
+  SELECT COALESCE(array_agg(pile_num ORDER BY pile_num), '{}') slot_pile_nums,
+    COALESCE(array_agg(row_num ORDER BY pile_num), '{}') slot_rows,
+    COALESCE(array_agg(column_num ORDER BY pile_num), '{}') slot_columns
+  FROM slot WHERE game_id = game.id
+) s ON TRUE JOIN LATERAL (
+  SELECT COALESCE(array_agg(pile_num ORDER BY pile_num, covered_by), '{}') cover_pile_nums,
+    COALESCE(array_agg(covered_by ORDER BY pile_num, covered_by), '{}') covered_by
+  FROM slot_cover WHERE game_id = game.id
+) c ON TRUE WHERE game.id = @game_id;
@@ -2,12 +2,36 @@
 
 package sqlc
 
-import ()
+import (
+	"database/sql"
+)
 
 type Game struct {
 	ID                  int64
 	Score               int32
 	MaxTimesThroughDeck int32
+	EmptyColumnFill     int16
+	Scoring             int16
+	DrawCount           int32
+	Status              int16
+	HintPenalty         int32
+	AllowUndo           bool
+	UndoPenalty         int32
+	Seed                int64
+	Salt                string
+	Commitment          string
+	Family              int16
+	SuitCount           int32
+	PileCount           int16
+	Jokers              int32
+	Ruleset             string
+	PreviousGameID      sql.NullInt64
+}
+
+type Hint struct {
+	ID         int64
+	GameID     int64
+	MoveNumber int32
 }
 
 type History struct {
@@ -15,6 +39,8 @@ type History struct {
 	GameID     int64
 	MoveID     int64
 	MoveNumber int32
+	Undone     bool
+	BoardHash  int64
 }
 
 type Move struct {
@@ -36,3 +62,18 @@ type PileCard struct {
 	Position  int32
 	GameID    int64
 }
+
+type Slot struct {
+	ID        int64
+	GameID    int64
+	PileNum   int16
+	RowNum    int16
+	ColumnNum int16
+}
+
+type SlotCover struct {
+	ID        int64
+	GameID    int64
+	PileNum   int16
+	CoveredBy int16
+}
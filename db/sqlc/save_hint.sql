@@ -0,0 +1,10 @@
+-- Record that a hint was given.
+-- name: SaveHint :exec
+
+WITH last_move AS (
+  SELECT COALESCE(max(move_number), 0) last_move_number
+  FROM history WHERE game_id = @game_id AND NOT undone
+)
+INSERT INTO hint (game_id, move_number)
+SELECT @game_id, last_move_number
+FROM last_move;
@@ -1,9 +1,14 @@
--- Make a move.
+-- Make a move numbered move_number, dropping any undone moves along with their move rows and keeping the hash of the new position. Nothing is returned when the game has moved on since.
 -- name: SavePerformMove :many
 
 WITH last_move AS (
   SELECT COALESCE(max(move_number), 0) last_move_number
-  FROM history WHERE game_id = @game_id
+  FROM history WHERE game_id = @game_id AND NOT undone
+), undone_moves AS (
+  DELETE FROM history WHERE game_id = @game_id AND undone
+  RETURNING move_id
+), orphaned_moves AS (
+  DELETE FROM move WHERE id IN (SELECT move_id FROM undone_moves)
 ), inserted_moves AS (
   INSERT INTO move (
     old_pile_num,
@@ -13,15 +18,16 @@ WITH last_move AS (
     new_pile_index,
     new_pile_position
   )
-  SELECT UNNEST(@old_pile_nums) AS old_pile_num,
-    UNNEST(@old_pile_indexes) AS old_pile_index,
-    UNNEST(@old_pile_positions) AS old_pile_position,
-    UNNEST(@new_pile_nums) AS new_pile_num,
-    UNNEST(@new_pile_indexes) AS new_pile_index,
-    UNNEST(@new_pile_positions) AS new_pile_position
+  SELECT UNNEST(@old_pile_nums::smallint[]) AS old_pile_num,
+    UNNEST(@old_pile_indexes::smallint[]) AS old_pile_index,
+    UNNEST(@old_pile_positions::smallint[]) AS old_pile_position,
+    UNNEST(@new_pile_nums::smallint[]) AS new_pile_num,
+    UNNEST(@new_pile_indexes::smallint[]) AS new_pile_index,
+    UNNEST(@new_pile_positions::smallint[]) AS new_pile_position
   RETURNING id AS move_id
 )
-INSERT INTO history (game_id, move_id, move_number)
-SELECT @game_id, last_move_number, move_id
+INSERT INTO history (game_id, move_number, move_id, board_hash)
+SELECT @game_id, last_move_number + 1, move_id, @board_hash::bigint
 FROM last_move, inserted_moves
+WHERE last_move_number + 1 = @move_number::integer
 RETURNING game_id, move_id;
@@ -11,7 +11,12 @@ const savePerformMove = `-- name: SavePerformMove :many
 
 WITH last_move AS (
   SELECT COALESCE(max(move_number), 0) last_move_number
-  FROM history WHERE game_id = $1
+  FROM history WHERE game_id = $1 AND NOT undone
+), undone_moves AS (
+  DELETE FROM history WHERE game_id = $1 AND undone
+  RETURNING move_id
+), orphaned_moves AS (
+  DELETE FROM move WHERE id IN (SELECT move_id FROM undone_moves)
 ), inserted_moves AS (
   INSERT INTO move (
     old_pile_num,
@@ -21,28 +26,31 @@ WITH last_move AS (
     new_pile_index,
     new_pile_position
   )
-  SELECT UNNEST($2) AS old_pile_num,
-    UNNEST($3) AS old_pile_index,
-    UNNEST($4) AS old_pile_position,
-    UNNEST($5) AS new_pile_num,
-    UNNEST($6) AS new_pile_index,
-    UNNEST($7) AS new_pile_position
+  SELECT UNNEST($2::smallint[]) AS old_pile_num,
+    UNNEST($3::smallint[]) AS old_pile_index,
+    UNNEST($4::smallint[]) AS old_pile_position,
+    UNNEST($5::smallint[]) AS new_pile_num,
+    UNNEST($6::smallint[]) AS new_pile_index,
+    UNNEST($7::smallint[]) AS new_pile_position
   RETURNING id AS move_id
 )
-INSERT INTO history (game_id, move_id, move_number)
-SELECT $1, last_move_number, move_id
+INSERT INTO history (game_id, move_number, move_id, board_hash)
+SELECT $1, last_move_number + 1, move_id, $8::bigint
 FROM last_move, inserted_moves
+WHERE last_move_number + 1 = $9::integer
 RETURNING game_id, move_id
 `
 
 type SavePerformMoveParams struct {
 	GameID           int64
-	OldPileNums      interface{}
-	OldPileIndexes   interface{}
-	OldPilePositions interface{}
-	NewPileNums      interface{}
-	NewPileIndexes   interface{}
-	NewPilePositions interface{}
+	OldPileNums      []int16
+	OldPileIndexes   []int16
+	OldPilePositions []int16
+	NewPileNums      []int16
+	NewPileIndexes   []int16
+	NewPilePositions []int16
+	BoardHash        int64
+	MoveNumber       int32
 }
 
 type SavePerformMoveRow struct {
@@ -50,7 +58,7 @@ type SavePerformMoveRow struct {
 	MoveID int64
 }
 
-// Make a move.
+// Make a move numbered move_number, dropping any undone moves along with their move rows and keeping the hash of the new position. Nothing is returned when the game has moved on since.
 func (q *Queries) SavePerformMove(ctx context.Context, arg SavePerformMoveParams) ([]SavePerformMoveRow, error) {
 	rows, err := q.db.Query(ctx, savePerformMove,
 		arg.GameID,
@@ -60,6 +68,8 @@ func (q *Queries) SavePerformMove(ctx context.Context, arg SavePerformMoveParams
 		arg.NewPileNums,
 		arg.NewPileIndexes,
 		arg.NewPilePositions,
+		arg.BoardHash,
+		arg.MoveNumber,
 	)
 	if err != nil {
 		return nil, err
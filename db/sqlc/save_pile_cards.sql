@@ -0,0 +1,21 @@
+-- Replace the cards of the affected piles.
+-- name: SavePileCards :exec
+
+WITH deleted_pile AS (
+  DELETE FROM pile_card
+  WHERE game_id = @game_id AND pile_num = ANY(@affected_pile_nums::smallint[])
+)
+INSERT INTO pile_card (
+  pile_num,
+  pile_index,
+  suit,
+  index,
+  position,
+  game_id
+)
+SELECT UNNEST(@pile_nums::smallint[]) AS pile_num,
+  UNNEST(@pile_indexes::smallint[]) AS pile_index,
+  UNNEST(@suits::smallint[]) AS suit,
+  UNNEST(@indexes::smallint[]) AS index,
+  UNNEST(@positions::integer[]) AS position,
+  @game_id AS game_id;
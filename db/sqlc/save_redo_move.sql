@@ -0,0 +1,7 @@
+-- Redo the last undone move.
+-- name: SaveRedoMove :exec
+
+UPDATE history SET undone = false
+WHERE game_id = @game_id AND move_number = (
+  SELECT min(move_number) FROM history WHERE game_id = @game_id AND undone
+);
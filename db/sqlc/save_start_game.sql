@@ -1,8 +1,8 @@
 -- Start a game.
 -- name: SaveStartGame :one
 WITH inserted_game AS (
-  INSERT INTO game (score, max_times_through_deck)
-  VALUES (@score, @max_times_through_deck)
+  INSERT INTO game (score, max_times_through_deck, empty_column_fill, scoring, draw_count, status, hint_penalty, allow_undo, undo_penalty, seed, salt, commitment, family, suit_count, pile_count, jokers, ruleset, previous_game_id)
+  VALUES (@score, @max_times_through_deck, @empty_column_fill, @scoring, @draw_count, @status, @hint_penalty, @allow_undo, @undo_penalty, @seed, @salt, @commitment, @family, @suit_count, @pile_count, @jokers, @ruleset, NULLIF(@previous_game_id::bigint, 0))
   RETURNING id, score, max_times_through_deck
 ),
 inserted_pile AS (
@@ -21,5 +21,29 @@ inserted_pile AS (
     UNNEST(@positions::integer[]) AS position,
     inserted_game.id AS game_id
   FROM inserted_game
+),
+inserted_slot AS (
+  INSERT INTO slot (
+    pile_num,
+    row_num,
+    column_num,
+    game_id
+  )
+  SELECT UNNEST(@slot_pile_nums::smallint[]) AS pile_num,
+    UNNEST(@slot_rows::smallint[]) AS row_num,
+    UNNEST(@slot_columns::smallint[]) AS column_num,
+    inserted_game.id AS game_id
+  FROM inserted_game
+),
+inserted_slot_cover AS (
+  INSERT INTO slot_cover (
+    pile_num,
+    covered_by,
+    game_id
+  )
+  SELECT UNNEST(@cover_pile_nums::smallint[]) AS pile_num,
+    UNNEST(@covered_by::smallint[]) AS covered_by,
+    inserted_game.id AS game_id
+  FROM inserted_game
 )
 SELECT id AS game_id FROM inserted_game;
@@ -9,8 +9,8 @@ import (
 
 const saveStartGame = `-- name: SaveStartGame :one
 WITH inserted_game AS (
-  INSERT INTO game (score, max_times_through_deck)
-  VALUES ($1, $2)
+  INSERT INTO game (score, max_times_through_deck, empty_column_fill, scoring, draw_count, status, hint_penalty, allow_undo, undo_penalty, seed, salt, commitment, family, suit_count, pile_count, jokers, ruleset, previous_game_id)
+  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, NULLIF($18::bigint, 0))
   RETURNING id, score, max_times_through_deck
 ),
 inserted_pile AS (
@@ -22,11 +22,35 @@ inserted_pile AS (
     position,
     game_id
   )
-  SELECT UNNEST($3::smallint[]) AS pile_num,
-    UNNEST($4::smallint[]) AS pile_index,
-    UNNEST($5::smallint[]) AS suit,
-    UNNEST($6::smallint[]) AS index,
-    UNNEST($7::integer[]) AS position,
+  SELECT UNNEST($19::smallint[]) AS pile_num,
+    UNNEST($20::smallint[]) AS pile_index,
+    UNNEST($21::smallint[]) AS suit,
+    UNNEST($22::smallint[]) AS index,
+    UNNEST($23::integer[]) AS position,
+    inserted_game.id AS game_id
+  FROM inserted_game
+),
+inserted_slot AS (
+  INSERT INTO slot (
+    pile_num,
+    row_num,
+    column_num,
+    game_id
+  )
+  SELECT UNNEST($24::smallint[]) AS pile_num,
+    UNNEST($25::smallint[]) AS row_num,
+    UNNEST($26::smallint[]) AS column_num,
+    inserted_game.id AS game_id
+  FROM inserted_game
+),
+inserted_slot_cover AS (
+  INSERT INTO slot_cover (
+    pile_num,
+    covered_by,
+    game_id
+  )
+  SELECT UNNEST($27::smallint[]) AS pile_num,
+    UNNEST($28::smallint[]) AS covered_by,
     inserted_game.id AS game_id
   FROM inserted_game
 )
@@ -36,11 +60,32 @@ SELECT id AS game_id FROM inserted_game
 type SaveStartGameParams struct {
 	Score               int32
 	MaxTimesThroughDeck int32
+	EmptyColumnFill     int16
+	Scoring             int16
+	DrawCount           int32
+	Status              int16
+	HintPenalty         int32
+	AllowUndo           bool
+	UndoPenalty         int32
+	Seed                int64
+	Salt                string
+	Commitment          string
+	Family              int16
+	SuitCount           int32
+	PileCount           int16
+	Jokers              int32
+	Ruleset             string
+	PreviousGameID      int64
 	PileNums            []int16
 	PileIndexes         []int16
 	Suits               []int16
 	Indexes             []int16
 	Positions           []int32
+	SlotPileNums        []int16
+	SlotRows            []int16
+	SlotColumns         []int16
+	CoverPileNums       []int16
+	CoveredBy           []int16
 }
 
 // Start a game.
@@ -48,11 +93,32 @@ func (q *Queries) SaveStartGame(ctx context.Context, arg SaveStartGameParams) (i
 	row := q.db.QueryRow(ctx, saveStartGame,
 		arg.Score,
 		arg.MaxTimesThroughDeck,
+		arg.EmptyColumnFill,
+		arg.Scoring,
+		arg.DrawCount,
+		arg.Status,
+		arg.HintPenalty,
+		arg.AllowUndo,
+		arg.UndoPenalty,
+		arg.Seed,
+		arg.Salt,
+		arg.Commitment,
+		arg.Family,
+		arg.SuitCount,
+		arg.PileCount,
+		arg.Jokers,
+		arg.Ruleset,
+		arg.PreviousGameID,
 		arg.PileNums,
 		arg.PileIndexes,
 		arg.Suits,
 		arg.Indexes,
 		arg.Positions,
+		arg.SlotPileNums,
+		arg.SlotRows,
+		arg.SlotColumns,
+		arg.CoverPileNums,
+		arg.CoveredBy,
 	)
 	var game_id int64
 	err := row.Scan(&game_id)
@@ -0,0 +1,7 @@
+-- Undo the last move.
+-- name: SaveUndoMove :exec
+
+UPDATE history SET undone = true
+WHERE game_id = @game_id AND move_number = (
+  SELECT max(move_number) FROM history WHERE game_id = @game_id AND NOT undone
+);
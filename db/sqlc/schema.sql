@@ -27,7 +27,23 @@ SET default_table_access_method = heap;
 CREATE TABLE public.game (
     id bigint NOT NULL,
     score integer DEFAULT 0 NOT NULL,
-    max_times_through_deck integer DEFAULT 1000 NOT NULL
+    max_times_through_deck integer DEFAULT 1000 NOT NULL,
+    empty_column_fill smallint DEFAULT 0 NOT NULL,
+    scoring smallint DEFAULT 0 NOT NULL,
+    draw_count integer DEFAULT 1 NOT NULL,
+    status smallint DEFAULT 0 NOT NULL,
+    hint_penalty integer DEFAULT 0 NOT NULL,
+    allow_undo boolean DEFAULT false NOT NULL,
+    undo_penalty integer DEFAULT 0 NOT NULL,
+    seed bigint DEFAULT 0 NOT NULL,
+    salt text DEFAULT ''::text NOT NULL,
+    commitment text DEFAULT ''::text NOT NULL,
+    family smallint DEFAULT 0 NOT NULL,
+    suit_count integer DEFAULT 0 NOT NULL,
+    pile_count smallint DEFAULT 13 NOT NULL,
+    jokers integer DEFAULT 0 NOT NULL,
+    ruleset text DEFAULT ''::text NOT NULL,
+    previous_game_id bigint
 );
 
 
@@ -54,6 +70,40 @@ ALTER TABLE public.game_id_seq OWNER TO poc;
 ALTER SEQUENCE public.game_id_seq OWNED BY public.game.id;
 
 
+--
+-- Name: hint; Type: TABLE; Schema: public; Owner: poc
+--
+
+CREATE TABLE public.hint (
+    id bigint NOT NULL,
+    game_id bigint NOT NULL,
+    move_number integer DEFAULT 0 NOT NULL
+);
+
+
+ALTER TABLE public.hint OWNER TO poc;
+
+--
+-- Name: hint_id_seq; Type: SEQUENCE; Schema: public; Owner: poc
+--
+
+CREATE SEQUENCE public.hint_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+ALTER TABLE public.hint_id_seq OWNER TO poc;
+
+--
+-- Name: hint_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: poc
+--
+
+ALTER SEQUENCE public.hint_id_seq OWNED BY public.hint.id;
+
+
 --
 -- Name: history; Type: TABLE; Schema: public; Owner: poc
 --
@@ -62,7 +112,9 @@ CREATE TABLE public.history (
     id bigint NOT NULL,
     game_id bigint NOT NULL,
     move_id bigint NOT NULL,
-    move_number integer DEFAULT 0 NOT NULL
+    move_number integer DEFAULT 0 NOT NULL,
+    undone boolean DEFAULT false NOT NULL,
+    board_hash bigint DEFAULT 0 NOT NULL
 );
 
 
@@ -165,6 +217,77 @@ ALTER TABLE public.pile_card_id_seq OWNER TO poc;
 ALTER SEQUENCE public.pile_card_id_seq OWNED BY public.pile_card.id;
 
 
+--
+-- Name: slot; Type: TABLE; Schema: public; Owner: poc
+--
+
+CREATE TABLE public.slot (
+    id bigint NOT NULL,
+    game_id bigint NOT NULL,
+    pile_num smallint DEFAULT 0 NOT NULL,
+    row_num smallint DEFAULT 0 NOT NULL,
+    column_num smallint DEFAULT 0 NOT NULL
+);
+
+
+ALTER TABLE public.slot OWNER TO poc;
+
+--
+-- Name: slot_id_seq; Type: SEQUENCE; Schema: public; Owner: poc
+--
+
+CREATE SEQUENCE public.slot_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+ALTER TABLE public.slot_id_seq OWNER TO poc;
+
+--
+-- Name: slot_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: poc
+--
+
+ALTER SEQUENCE public.slot_id_seq OWNED BY public.slot.id;
+
+
+--
+-- Name: slot_cover; Type: TABLE; Schema: public; Owner: poc
+--
+
+CREATE TABLE public.slot_cover (
+    id bigint NOT NULL,
+    game_id bigint NOT NULL,
+    pile_num smallint DEFAULT 0 NOT NULL,
+    covered_by smallint DEFAULT 0 NOT NULL
+);
+
+
+ALTER TABLE public.slot_cover OWNER TO poc;
+
+--
+-- Name: slot_cover_id_seq; Type: SEQUENCE; Schema: public; Owner: poc
+--
+
+CREATE SEQUENCE public.slot_cover_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+ALTER TABLE public.slot_cover_id_seq OWNER TO poc;
+
+--
+-- Name: slot_cover_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: poc
+--
+
+ALTER SEQUENCE public.slot_cover_id_seq OWNED BY public.slot_cover.id;
+
+
 --
 -- Name: game id; Type: DEFAULT; Schema: public; Owner: poc
 --
@@ -172,6 +295,13 @@ ALTER SEQUENCE public.pile_card_id_seq OWNED BY public.pile_card.id;
 ALTER TABLE ONLY public.game ALTER COLUMN id SET DEFAULT nextval('public.game_id_seq'::regclass);
 
 
+--
+-- Name: hint id; Type: DEFAULT; Schema: public; Owner: poc
+--
+
+ALTER TABLE ONLY public.hint ALTER COLUMN id SET DEFAULT nextval('public.hint_id_seq'::regclass);
+
+
 --
 -- Name: history id; Type: DEFAULT; Schema: public; Owner: poc
 --
@@ -193,6 +323,20 @@ ALTER TABLE ONLY public.move ALTER COLUMN id SET DEFAULT nextval('public.move_id
 ALTER TABLE ONLY public.pile_card ALTER COLUMN id SET DEFAULT nextval('public.pile_card_id_seq'::regclass);
 
 
+--
+-- Name: slot id; Type: DEFAULT; Schema: public; Owner: poc
+--
+
+ALTER TABLE ONLY public.slot ALTER COLUMN id SET DEFAULT nextval('public.slot_id_seq'::regclass);
+
+
+--
+-- Name: slot_cover id; Type: DEFAULT; Schema: public; Owner: poc
+--
+
+ALTER TABLE ONLY public.slot_cover ALTER COLUMN id SET DEFAULT nextval('public.slot_cover_id_seq'::regclass);
+
+
 --
 -- Name: game game_pkey; Type: CONSTRAINT; Schema: public; Owner: poc
 --
@@ -201,6 +345,22 @@ ALTER TABLE ONLY public.game
     ADD CONSTRAINT game_pkey PRIMARY KEY (id);
 
 
+--
+-- Name: game game_previous_game_id_key; Type: CONSTRAINT; Schema: public; Owner: poc
+--
+
+ALTER TABLE ONLY public.game
+    ADD CONSTRAINT game_previous_game_id_key UNIQUE (previous_game_id);
+
+
+--
+-- Name: hint hint_pkey; Type: CONSTRAINT; Schema: public; Owner: poc
+--
+
+ALTER TABLE ONLY public.hint
+    ADD CONSTRAINT hint_pkey PRIMARY KEY (id);
+
+
 --
 -- Name: history history_game_move_uniqueness; Type: CONSTRAINT; Schema: public; Owner: poc
 --
@@ -233,6 +393,38 @@ ALTER TABLE ONLY public.pile_card
     ADD CONSTRAINT pile_card_pkey PRIMARY KEY (id);
 
 
+--
+-- Name: slot slot_pkey; Type: CONSTRAINT; Schema: public; Owner: poc
+--
+
+ALTER TABLE ONLY public.slot
+    ADD CONSTRAINT slot_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: slot_cover slot_cover_pkey; Type: CONSTRAINT; Schema: public; Owner: poc
+--
+
+ALTER TABLE ONLY public.slot_cover
+    ADD CONSTRAINT slot_cover_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: game game_previous_game_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: poc
+--
+
+ALTER TABLE ONLY public.game
+    ADD CONSTRAINT game_previous_game_id_fkey FOREIGN KEY (previous_game_id) REFERENCES public.game(id) ON DELETE SET NULL;
+
+
+--
+-- Name: hint hint_game_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: poc
+--
+
+ALTER TABLE ONLY public.hint
+    ADD CONSTRAINT hint_game_id_fkey FOREIGN KEY (game_id) REFERENCES public.game(id) ON DELETE CASCADE;
+
+
 --
 -- Name: history history_game_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: poc
 --
@@ -257,6 +449,22 @@ ALTER TABLE ONLY public.pile_card
     ADD CONSTRAINT pile_card_game_id_fkey FOREIGN KEY (game_id) REFERENCES public.game(id);
 
 
+--
+-- Name: slot slot_game_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: poc
+--
+
+ALTER TABLE ONLY public.slot
+    ADD CONSTRAINT slot_game_id_fkey FOREIGN KEY (game_id) REFERENCES public.game(id) ON DELETE CASCADE;
+
+
+--
+-- Name: slot_cover slot_cover_game_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: poc
+--
+
+ALTER TABLE ONLY public.slot_cover
+    ADD CONSTRAINT slot_cover_game_id_fkey FOREIGN KEY (game_id) REFERENCES public.game(id) ON DELETE CASCADE;
+
+
 --
 -- PostgreSQL database dump complete
 --
@@ -0,0 +1,5 @@
+-- Update the game state.
+-- name: UpdateGame :exec
+
+UPDATE game SET score = @score, status = @status
+WHERE id = @game_id;
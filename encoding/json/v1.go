@@ -2,23 +2,160 @@ package json
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/slcjordan/poc"
 )
 
 type v1Variant struct {
-	MaxTimesThroughDeck int32 `json:"max_times_through_deck"`
+	Ruleset             string `json:"ruleset"`
+	Family              string `json:"family"`
+	Suits               int32  `json:"suits"`
+	Jokers              int32  `json:"jokers"`
+	MaxTimesThroughDeck int32  `json:"max_times_through_deck"`
+	EmptyColumnFill     string `json:"empty_column_fill"`
+	Scoring             string `json:"scoring"`
+	DrawCount           int32  `json:"draw_count"`
+	HintPenalty         int32  `json:"hint_penalty"`
+	AllowUndo           bool   `json:"allow_undo"`
+	UndoPenalty         int32  `json:"undo_penalty"`
+}
+
+var v1Families = map[poc.Family]string{
+	poc.Klondike: "klondike",
+	poc.Spider:   "spider",
+	poc.FreeCell: "freecell",
+	poc.Pyramid:  "pyramid",
+	poc.TriPeaks: "tripeaks",
+}
+
+func v1LookupFamily(desc string) (poc.Family, error) {
+	if desc == "" {
+		return poc.Klondike, nil
+	}
+	for family, curr := range v1Families {
+		if curr == desc {
+			return family, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown family %#v", desc)
+}
+
+type v1PileKind struct {
+	kind  string
+	count int
+}
+
+// v1Layouts names what each pile of a game family is for, in pile order, so
+// that clients can draw boards without knowing the pile numbering.
+var v1Layouts = map[poc.Family][]v1PileKind{
+	poc.Klondike: {{"stock", 1}, {"talon", 1}, {"tableau", 7}, {"foundation", 4}},
+	poc.Spider:   {{"stock", 1}, {"tableau", 10}, {"foundation", 8}},
+	poc.FreeCell: {{"cell", 4}, {"tableau", 8}, {"foundation", 4}},
+	poc.Pyramid:  {{"stock", 1}, {"talon", 1}, {"tableau", 28}, {"foundation", 1}},
+	poc.TriPeaks: {{"stock", 1}, {"talon", 1}, {"tableau", 28}},
+}
+
+func v1Layout(family poc.Family) []string {
+	var result []string
+	for _, pileKind := range v1Layouts[family] {
+		for i := 0; i < pileKind.count; i++ {
+			result = append(result, pileKind.kind)
+		}
+	}
+	return result
+}
+
+var v1Scorings = map[poc.Scoring]string{
+	poc.StandardScoring: "standard",
+	poc.VegasScoring:    "vegas",
+}
+
+func v1LookupScoring(desc string) (poc.Scoring, error) {
+	if desc == "" {
+		return poc.StandardScoring, nil
+	}
+	for scoring, curr := range v1Scorings {
+		if curr == desc {
+			return scoring, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown scoring %#v", desc)
+}
+
+var v1ColumnFills = map[poc.ColumnFill]string{
+	poc.KingsOnlyFill: "kings_only",
+	poc.AnyCardFill:   "any_card",
+	poc.NoCardFill:    "no_card",
+}
+
+func v1LookupColumnFill(desc string) (poc.ColumnFill, error) {
+	if desc == "" {
+		return poc.KingsOnlyFill, nil
+	}
+	for fill, curr := range v1ColumnFills {
+		if curr == desc {
+			return fill, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown empty column fill %#v", desc)
+}
+
+var v1GameStatuses = map[poc.GameStatus]string{
+	poc.InProgress: "in_progress",
+	poc.Won:        "won",
+	poc.Stuck:      "stuck",
+	poc.Resigned:   "resigned",
+}
+
+var v1Solvabilities = map[poc.Solvability]string{
+	poc.Winnable:           "winnable",
+	poc.Unwinnable:         "unwinnable",
+	poc.SearchLimitReached: "search_limit_reached",
+}
+
+var v1HintReasons = map[poc.HintReason]string{
+	poc.ToFoundation:   "to_foundation",
+	poc.RevealCard:     "reveal_card",
+	poc.EmptyColumn:    "empty_column",
+	poc.PlayTalon:      "play_talon",
+	poc.BuildTableau:   "build_tableau",
+	poc.DrawStock:      "draw_stock",
+	poc.RecycleTalon:   "recycle_talon",
+	poc.FromFoundation: "from_foundation",
+}
+
+type v1StartGame struct {
+	v1Variant
+	WinnableOnly   bool  `json:"winnable_only"`
+	Seed           int64 `json:"seed"`
+	PreviousGameID int64 `json:"previous_game_id"`
 }
 
 type v1PositionedCard struct {
 	Position []string `json:"position"`
 	Suit     string   `json:"suit"`
 	Index    string   `json:"index"`
+	Wild     bool     `json:"wild"`
+}
+
+type v1Slot struct {
+	PileNum int `json:"pile_num"`
+	Row     int `json:"row"`
+	Column  int `json:"column"`
+}
+
+type v1Cover struct {
+	PileNum   int `json:"pile_num"`
+	CoveredBy int `json:"covered_by"`
 }
 
 type v1Board struct {
-	Piles [13][]v1PositionedCard `json:"piles"`
-	Score int32                  `json:"score"`
+	Layout []string             `json:"layout"`
+	Piles  [][]v1PositionedCard `json:"piles"`
+	Slots  []v1Slot             `json:"slots"`
+	Covers []v1Cover            `json:"covers"`
+	Score  int32                `json:"score"`
 }
 
 type v1Move struct {
@@ -34,8 +171,15 @@ type v1SavedGameDetail struct {
 	GameID            int64      `json:"game_id"`
 	Board             v1Board    `json:"board"`
 	History           [][]v1Move `json:"history"`
+	Redo              [][]v1Move `json:"redo"`
 	PossibleNextMoves [][]v1Move `json:"possible_moves"`
 	Variant           v1Variant  `json:"variant"`
+	Status            string     `json:"status"`
+	Looping           bool       `json:"looping"`
+	HintsUsed         int32      `json:"hints_used"`
+	Seed              int64      `json:"seed,omitempty"`
+	Commitment        string     `json:"commitment"`
+	PreviousGameID    int64      `json:"previous_game_id,omitempty"`
 }
 
 func v1LookupPosition(desc []string) poc.Position {
@@ -70,36 +214,87 @@ func toV1Moves(moves [][]poc.Move) [][]v1Move {
 	return result
 }
 
-func toV1Piles(cards [13][]poc.PositionedCard) [13][]v1PositionedCard {
-	var result [13][]v1PositionedCard
+// toV1Piles lists the cards of each pile. Face down cards are only named once
+// the deck is revealed.
+func toV1Piles(cards [][]poc.PositionedCard, revealed bool) [][]v1PositionedCard {
+	result := make([][]v1PositionedCard, len(cards))
 	lookupV1Move := map[poc.Position][]string{
 		poc.FaceUp: {"face_up"},
 		0:          {},
 	}
-	for idx := 0; idx < 13; idx++ {
+	for idx := range cards {
 		result[idx] = make([]v1PositionedCard, len(cards[idx]))
 		for i := range cards[idx] {
 			result[idx][i].Position = lookupV1Move[cards[idx][i].Position]
+			if cards[idx][i].Position&poc.FaceUp == 0 && !revealed {
+				continue
+			}
 			result[idx][i].Suit = cards[idx][i].Card.Suit.String()
 			result[idx][i].Index = cards[idx][i].Card.Index.String()
+			result[idx][i].Wild = cards[idx][i].Card.Suit == poc.Joker
 		}
 	}
 	return result
 }
 
+func toV1Slots(slots []poc.Slot) []v1Slot {
+	result := make([]v1Slot, len(slots))
+	for i, slot := range slots {
+		result[i] = v1Slot{PileNum: slot.PileNum, Row: slot.Row, Column: slot.Column}
+	}
+	return result
+}
+
+func toV1Covers(covers []poc.Cover) []v1Cover {
+	result := make([]v1Cover, len(covers))
+	for i, cover := range covers {
+		result[i] = v1Cover{PileNum: cover.PileNum, CoveredBy: cover.CoveredBy}
+	}
+	return result
+}
+
+func toV1Board(family poc.Family, board poc.Board, revealed bool) v1Board {
+	return v1Board{
+		Layout: v1Layout(family),
+		Piles:  toV1Piles(board.Piles, revealed),
+		Slots:  toV1Slots(board.Slots),
+		Covers: toV1Covers(board.Covers),
+		Score:  board.Score,
+	}
+}
+
+// toV1SavedGame leaves out the seed and the face down cards until the deck is
+// revealed, since either gives away the deck order.
 func toV1SavedGame(saved poc.SavedGameDetail) v1SavedGameDetail {
-	return v1SavedGameDetail{
-		GameID: saved.GameID,
-		Board: v1Board{
-			Piles: toV1Piles(saved.Board.Piles),
-			Score: saved.Board.Score,
-		},
+	result := v1SavedGameDetail{
+		GameID:            saved.GameID,
+		Board:             toV1Board(saved.Variant.Family, saved.Board, saved.Revealed()),
 		History:           toV1Moves(saved.History),
+		Redo:              toV1Moves(saved.Redo),
 		PossibleNextMoves: toV1Moves(saved.PossibleNextMoves),
 		Variant: v1Variant{
+			Ruleset:             saved.Variant.Name,
+			Family:              v1Families[saved.Variant.Family],
+			Suits:               saved.Variant.Suits,
+			Jokers:              saved.Variant.Jokers,
 			MaxTimesThroughDeck: saved.Variant.MaxTimesThroughDeck,
+			EmptyColumnFill:     v1ColumnFills[saved.Variant.EmptyColumnFill],
+			Scoring:             v1Scorings[saved.Variant.Scoring],
+			DrawCount:           saved.Variant.DrawCount,
+			HintPenalty:         saved.Variant.HintPenalty,
+			AllowUndo:           saved.Variant.AllowUndo,
+			UndoPenalty:         saved.Variant.UndoPenalty,
 		},
+		Status:         v1GameStatuses[saved.Status],
+		Looping:        saved.Looping,
+		HintsUsed:      saved.HintsUsed,
+		Commitment:     saved.Commitment,
+		PreviousGameID: saved.PreviousGameID,
 	}
+	if saved.Revealed() {
+		result.Seed = saved.Seed
+	}
+	return result
 }
 
 // V1 json encoding.
@@ -107,14 +302,41 @@ type V1 struct{}
 
 // DecodeStartGame unmarshals start game input.
 func (v V1) DecodeStartGame(b []byte) (poc.StartGame, error) {
-	var variant v1Variant
+	var variant v1StartGame
 	err := json.Unmarshal(b, &variant)
 	if err != nil {
 		return poc.StartGame{}, err
 	}
+	fill, err := v1LookupColumnFill(variant.EmptyColumnFill)
+	if err != nil {
+		return poc.StartGame{}, err
+	}
+	scoring, err := v1LookupScoring(variant.Scoring)
+	if err != nil {
+		return poc.StartGame{}, err
+	}
+	family, err := v1LookupFamily(variant.Family)
+	if err != nil {
+		return poc.StartGame{}, err
+	}
 	return poc.StartGame{
 		Variant: poc.Variant{
+			Name:                variant.Ruleset,
+			Family:              family,
+			Suits:               variant.Suits,
+			Jokers:              variant.Jokers,
 			MaxTimesThroughDeck: variant.MaxTimesThroughDeck,
+			EmptyColumnFill:     fill,
+			Scoring:             scoring,
+			DrawCount:           variant.DrawCount,
+			HintPenalty:         variant.HintPenalty,
+			AllowUndo:           variant.AllowUndo,
+			UndoPenalty:         variant.UndoPenalty,
+		},
+		WinnableOnly: variant.WinnableOnly,
+		Seed:         variant.Seed,
+		SavedGameDetail: poc.SavedGameDetail{
+			PreviousGameID: variant.PreviousGameID,
 		},
 	}, nil
 }
@@ -153,6 +375,207 @@ func (v V1) EncodePerformMove(performed poc.PerformMove) ([]byte, error) {
 	return json.Marshal(result)
 }
 
+// DecodeLookupGame unmarshals lookup game input.
+func (v V1) DecodeLookupGame(b []byte) (poc.LookupGame, error) {
+	var result poc.LookupGame
+	return result, nil
+}
+
+// EncodeLookupGame marshals lookup game result.
+func (v V1) EncodeLookupGame(game poc.LookupGame) ([]byte, error) {
+	result := toV1SavedGame(game.SavedGameDetail)
+	return json.Marshal(result)
+}
+
+// DecodeAutoComplete unmarshals auto complete input.
+func (v V1) DecodeAutoComplete(b []byte) (poc.AutoComplete, error) {
+	var result poc.AutoComplete
+	return result, nil
+}
+
+// EncodeAutoComplete marshals auto complete result.
+func (v V1) EncodeAutoComplete(game poc.AutoComplete) ([]byte, error) {
+	result := toV1SavedGame(game.SavedGameDetail)
+	return json.Marshal(result)
+}
+
+// DecodeSolveGame unmarshals solve game input. The body is optional.
+func (v V1) DecodeSolveGame(b []byte) (poc.SolveGame, error) {
+	var result poc.SolveGame
+	if len(b) == 0 {
+		return result, nil
+	}
+	var limits struct {
+		MaxNodes int32 `json:"max_nodes"`
+	}
+	err := json.Unmarshal(b, &limits)
+	if err != nil {
+		return result, err
+	}
+	result.MaxNodes = limits.MaxNodes
+	return result, nil
+}
+
+// EncodeSolveGame marshals solve game result.
+func (v V1) EncodeSolveGame(game poc.SolveGame) ([]byte, error) {
+	return json.Marshal(struct {
+		GameID        int64      `json:"game_id"`
+		Solvability   string     `json:"solvability"`
+		NodesSearched int32      `json:"nodes_searched"`
+		Solution      [][]v1Move `json:"solution"`
+	}{
+		GameID:        game.SavedGameDetail.GameID,
+		Solvability:   v1Solvabilities[game.Solvability],
+		NodesSearched: game.NodesSearched,
+		Solution:      toV1Moves(game.Solution),
+	})
+}
+
+// DecodeHint unmarshals hint input.
+func (v V1) DecodeHint(b []byte) (poc.Hint, error) {
+	var result poc.Hint
+	return result, nil
+}
+
+// EncodeHint marshals hint result.
+func (v V1) EncodeHint(game poc.Hint) ([]byte, error) {
+	type v1Hint struct {
+		Moves  []v1Move `json:"moves"`
+		Score  int32    `json:"score"`
+		Reason string   `json:"reason"`
+	}
+	hints := make([]v1Hint, len(game.Moves))
+	for i, curr := range game.Moves {
+		hints[i] = v1Hint{
+			Moves:  toV1Moves([][]poc.Move{curr.Moves})[0],
+			Score:  curr.Score,
+			Reason: v1HintReasons[curr.Reason],
+		}
+	}
+	return json.Marshal(struct {
+		GameID    int64    `json:"game_id"`
+		Score     int32    `json:"score"`
+		HintsUsed int32    `json:"hints_used"`
+		Hints     []v1Hint `json:"hints"`
+	}{
+		GameID:    game.SavedGameDetail.GameID,
+		Score:     game.SavedGameDetail.Board.Score,
+		HintsUsed: game.SavedGameDetail.HintsUsed,
+		Hints:     hints,
+	})
+}
+
+// DecodeResignGame unmarshals resign game input.
+func (v V1) DecodeResignGame(b []byte) (poc.ResignGame, error) {
+	var result poc.ResignGame
+	return result, nil
+}
+
+// EncodeResignGame marshals resign game result.
+func (v V1) EncodeResignGame(game poc.ResignGame) ([]byte, error) {
+	result := toV1SavedGame(game.SavedGameDetail)
+	return json.Marshal(result)
+}
+
+// DecodeUndoMove unmarshals undo move input.
+func (v V1) DecodeUndoMove(b []byte) (poc.UndoMove, error) {
+	var result poc.UndoMove
+	return result, nil
+}
+
+// EncodeUndoMove marshals undo move result.
+func (v V1) EncodeUndoMove(game poc.UndoMove) ([]byte, error) {
+	result := toV1SavedGame(game.SavedGameDetail)
+	return json.Marshal(result)
+}
+
+// DecodeRedoMove unmarshals redo move input.
+func (v V1) DecodeRedoMove(b []byte) (poc.RedoMove, error) {
+	var result poc.RedoMove
+	return result, nil
+}
+
+// EncodeRedoMove marshals redo move result.
+func (v V1) EncodeRedoMove(game poc.RedoMove) ([]byte, error) {
+	result := toV1SavedGame(game.SavedGameDetail)
+	return json.Marshal(result)
+}
+
+type v1Card struct {
+	Suit  string `json:"suit"`
+	Index string `json:"index"`
+}
+
+type v1Fairness struct {
+	GameID     int64    `json:"game_id"`
+	Status     string   `json:"status"`
+	Commitment string   `json:"commitment"`
+	Revealed   bool     `json:"revealed"`
+	Salt       string   `json:"salt,omitempty"`
+	Deck       []v1Card `json:"deck,omitempty"`
+}
+
+// DecodeFairness unmarshals fairness input.
+func (v V1) DecodeFairness(b []byte) (poc.Fairness, error) {
+	var result poc.Fairness
+	return result, nil
+}
+
+// EncodeFairness marshals fairness result. The salt and deck are left out
+// until they are revealed.
+func (v V1) EncodeFairness(game poc.Fairness) ([]byte, error) {
+	result := v1Fairness{
+		GameID:     game.SavedGameDetail.GameID,
+		Status:     v1GameStatuses[game.SavedGameDetail.Status],
+		Commitment: game.SavedGameDetail.Commitment,
+		Revealed:   game.Revealed,
+	}
+	if game.Revealed {
+		result.Salt = game.SavedGameDetail.Salt
+		for _, card := range game.Deck {
+			result.Deck = append(result.Deck, v1Card{
+				Suit:  card.Suit.String(),
+				Index: card.Index.String(),
+			})
+		}
+	}
+	return json.Marshal(result)
+}
+
+type v1Replay struct {
+	GameID           int64     `json:"game_id"`
+	MoveNumber       int32     `json:"move_number"`
+	Board            v1Board   `json:"board"`
+	Boards           []v1Board `json:"boards,omitempty"`
+	Status           string    `json:"status"`
+	InconsistentMove int32     `json:"inconsistent_move,omitempty"`
+	Problem          string    `json:"problem,omitempty"`
+}
+
+// DecodeReplay unmarshals replay input.
+func (v V1) DecodeReplay(b []byte) (poc.Replay, error) {
+	var result poc.Replay
+	return result, nil
+}
+
+// EncodeReplay marshals replay result. Boards are only listed when the
+// replay was streamed.
+func (v V1) EncodeReplay(game poc.Replay) ([]byte, error) {
+	family := game.SavedGameDetail.Variant.Family
+	result := v1Replay{
+		GameID:           game.SavedGameDetail.GameID,
+		MoveNumber:       game.MoveNumber,
+		Board:            toV1Board(family, game.Board, game.SavedGameDetail.Revealed()),
+		Status:           v1GameStatuses[game.Status],
+		InconsistentMove: game.Inconsistent,
+		Problem:          game.Problem,
+	}
+	for _, board := range game.Boards {
+		result.Boards = append(result.Boards, toV1Board(family, board, game.SavedGameDetail.Revealed()))
+	}
+	return json.Marshal(result)
+}
+
 // DecodeListGames unmarshals list games input.
 func (v V1) DecodeListGames(b []byte) (poc.ListGames, error) {
 	var result poc.ListGames
@@ -0,0 +1,85 @@
+package json_test
+
+import (
+	"context"
+	stdjson "encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/slcjordan/poc"
+	"github.com/slcjordan/poc/encoding/json"
+	"github.com/slcjordan/poc/rules"
+)
+
+type encodedGame struct {
+	Seed  *int64 `json:"seed"`
+	Board struct {
+		Piles [][]struct {
+			Position []string `json:"position"`
+			Suit     string   `json:"suit"`
+			Index    string   `json:"index"`
+		} `json:"piles"`
+	} `json:"board"`
+}
+
+// encodeDeal deals a Klondike game and encodes it with the given status.
+func encodeDeal(t *testing.T, status poc.GameStatus) encodedGame {
+	start, err := rules.Shuffle{Source: rand.New(rand.NewSource(1))}.CallStartGame(context.Background(), poc.StartGame{Seed: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	start.SavedGameDetail.Status = status
+	b, err := json.V1{}.EncodeStartGame(start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result encodedGame
+	err = stdjson.Unmarshal(b, &result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+// faceDown counts the face down cards and how many of them are named.
+func faceDown(game encodedGame) (cards int, named int) {
+	for _, pile := range game.Board.Piles {
+		for _, card := range pile {
+			if len(card.Position) > 0 {
+				continue
+			}
+			cards++
+			if card.Suit != "" || card.Index != "" {
+				named++
+			}
+		}
+	}
+	return cards, named
+}
+
+// TestHiddenDeck checks that nothing in a game in progress gives away the
+// order of the deck, and that it is all shown once the deck is revealed.
+func TestHiddenDeck(t *testing.T) {
+	inProgress := encodeDeal(t, poc.InProgress)
+	if inProgress.Seed != nil {
+		t.Errorf("seed %d was sent for a game in progress", *inProgress.Seed)
+	}
+	cards, named := faceDown(inProgress)
+	if cards == 0 || named != 0 {
+		t.Errorf("%d of %d face down cards were named for a game in progress", named, cards)
+	}
+
+	stuck := encodeDeal(t, poc.Stuck)
+	if stuck.Seed != nil {
+		t.Errorf("seed %d was sent for a stuck game", *stuck.Seed)
+	}
+
+	resigned := encodeDeal(t, poc.Resigned)
+	if resigned.Seed == nil || *resigned.Seed != 7 {
+		t.Errorf("seed was not sent for a resigned game")
+	}
+	cards, named = faceDown(resigned)
+	if named != cards {
+		t.Errorf("%d of %d face down cards were named for a resigned game", named, cards)
+	}
+}
@@ -16,6 +16,8 @@ const (
 	UnimplementedError
 	NotFoundError
 	UnknownError
+	ConflictError
+	IntegrityError
 )
 
 // Error wraps an existing error and assigns it a category.
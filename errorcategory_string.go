@@ -14,11 +14,13 @@ func _() {
 	_ = x[UnimplementedError-4]
 	_ = x[NotFoundError-5]
 	_ = x[UnknownError-6]
+	_ = x[ConflictError-7]
+	_ = x[IntegrityError-8]
 }
 
-const _ErrorCategory_name = "SemanticErrorMalformedErrorUnavailableErrorUnimplementedErrorNotFoundErrorUnknownError"
+const _ErrorCategory_name = "SemanticErrorMalformedErrorUnavailableErrorUnimplementedErrorNotFoundErrorUnknownErrorConflictErrorIntegrityError"
 
-var _ErrorCategory_index = [...]uint8{0, 13, 27, 43, 61, 74, 86}
+var _ErrorCategory_index = [...]uint8{0, 13, 27, 43, 61, 74, 86, 99, 113}
 
 func (i ErrorCategory) String() string {
 	i -= 1
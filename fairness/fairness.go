@@ -0,0 +1,51 @@
+// Package fairness commits to a deck order before a game is played so that
+// players can check afterwards that the deal was not changed.
+//
+// A commitment is the hex encoded sha256 of the salt followed by two bytes
+// for each card in deck order: the suit and then the index.
+package fairness
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"io"
+
+	"github.com/slcjordan/poc"
+)
+
+// SaltSize is the number of random bytes in a salt.
+const SaltSize = 16
+
+// NewSalt reads a hex encoded salt from source.
+func NewSalt(source io.Reader) (string, error) {
+	salt := make([]byte, SaltSize)
+	_, err := io.ReadFull(source, salt)
+	if err != nil {
+		return "", fmt.Errorf("could not read salt: %w", err)
+	}
+	return hex.EncodeToString(salt), nil
+}
+
+// Commit hashes the hex encoded salt and the deck order.
+func Commit(salt string, deck []poc.Card) (string, error) {
+	b, err := hex.DecodeString(salt)
+	if err != nil {
+		return "", fmt.Errorf("could not decode salt: %w", err)
+	}
+	for _, card := range deck {
+		b = append(b, byte(card.Suit), byte(card.Index))
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Verify reports whether commitment was made for salt and deck.
+func Verify(commitment string, salt string, deck []poc.Card) bool {
+	expected, err := Commit(salt, deck)
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(commitment)) == 1
+}
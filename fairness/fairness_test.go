@@ -0,0 +1,47 @@
+package fairness_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/slcjordan/poc"
+	"github.com/slcjordan/poc/fairness"
+)
+
+func TestVerify(t *testing.T) {
+	deck := []poc.Card{
+		{Suit: poc.Hearts, Index: poc.Ace},
+		{Suit: poc.Spades, Index: poc.King},
+	}
+	swapped := []poc.Card{deck[1], deck[0]}
+	salt, err := fairness.NewSalt(strings.NewReader("0123456789abcdef"))
+	if err != nil {
+		t.Fatalf("could not make salt: %s", err)
+	}
+	commitment, err := fairness.Commit(salt, deck)
+	if err != nil {
+		t.Fatalf("could not commit: %s", err)
+	}
+	for _, testCase := range []struct {
+		Desc     string
+		Salt     string
+		Deck     []poc.Card
+		Expected bool
+	}{
+		{"same deck", salt, deck, true},
+		{"deck order changed", salt, swapped, false},
+		{"salt changed", "00" + salt[2:], deck, false},
+		{"salt is not hex", "salt", deck, false},
+	} {
+		t.Run(testCase.Desc, func(t *testing.T) {
+			actual := fairness.Verify(commitment, testCase.Salt, testCase.Deck)
+			if actual != testCase.Expected {
+				t.Errorf("expected %t but got %t", testCase.Expected, actual)
+			}
+		})
+	}
+	_, err = fairness.NewSalt(strings.NewReader("short"))
+	if err == nil {
+		t.Errorf("expected an error reading a short salt")
+	}
+}
@@ -78,7 +78,7 @@ type ListGamesEncoding interface {
 	DecodeListGames([]byte) (poc.ListGames, error)
 }
 
-//Pipelinemes command turns a listGames command (usually a pipeline) into a []byte command.
+// Pipelinemes command turns a listGames command (usually a pipeline) into a []byte command.
 type ListGames struct {
 	Encoding ListGamesEncoding
 	Pipeline poc.ListGamesCaller
@@ -101,3 +101,282 @@ func (l ListGames) CallBytes(ctx context.Context, b []byte) ([]byte, error) {
 	}
 	return result, nil
 }
+
+// LookupGameEncoding may deserialize a lookupGame input and serialize a
+// lookupGame result.
+type LookupGameEncoding interface {
+	EncodeLookupGame(poc.LookupGame) ([]byte, error)
+	DecodeLookupGame([]byte) (poc.LookupGame, error)
+}
+
+// LookupGame command turns a lookupGame command (usually a pipeline) into a []byte command.
+type LookupGame struct {
+	Encoding LookupGameEncoding
+	Pipeline poc.LookupGameCaller
+}
+
+// CallBytes forwards parsed bytes to the LookupGame command.
+func (l LookupGame) CallBytes(ctx context.Context, b []byte) ([]byte, error) {
+	game, err := l.Encoding.DecodeLookupGame(b)
+	if err != nil {
+		return nil, poc.Error{Actual: fmt.Errorf("could not decode request: %w", err), Category: poc.MalformedError}
+	}
+	game, err = l.Pipeline.CallLookupGame(ctx, game)
+	if err != nil {
+		return nil, err
+	}
+	result, err := l.Encoding.EncodeLookupGame(game)
+	if err != nil {
+		logger.Errorf(ctx, "could not encode lookup game response %#v: %s", game, err)
+		return nil, poc.Error{Actual: errors.New("could not encode response"), Category: poc.UnknownError}
+	}
+	return result, nil
+}
+
+// ResignGameEncoding may deserialize a resignGame input and serialize a
+// resignGame result.
+type ResignGameEncoding interface {
+	EncodeResignGame(poc.ResignGame) ([]byte, error)
+	DecodeResignGame([]byte) (poc.ResignGame, error)
+}
+
+// ResignGame command turns a resignGame command (usually a pipeline) into a []byte command.
+type ResignGame struct {
+	Encoding ResignGameEncoding
+	Pipeline poc.ResignGameCaller
+}
+
+// CallBytes forwards parsed bytes to the ResignGame command.
+func (r ResignGame) CallBytes(ctx context.Context, b []byte) ([]byte, error) {
+	game, err := r.Encoding.DecodeResignGame(b)
+	if err != nil {
+		return nil, poc.Error{Actual: fmt.Errorf("could not decode request: %w", err), Category: poc.MalformedError}
+	}
+	game, err = r.Pipeline.CallResignGame(ctx, game)
+	if err != nil {
+		return nil, err
+	}
+	result, err := r.Encoding.EncodeResignGame(game)
+	if err != nil {
+		logger.Errorf(ctx, "could not encode resign game response %#v: %s", game, err)
+		return nil, poc.Error{Actual: errors.New("could not encode response"), Category: poc.UnknownError}
+	}
+	return result, nil
+}
+
+// AutoCompleteEncoding may deserialize a autoComplete input and serialize a
+// autoComplete result.
+type AutoCompleteEncoding interface {
+	EncodeAutoComplete(poc.AutoComplete) ([]byte, error)
+	DecodeAutoComplete([]byte) (poc.AutoComplete, error)
+}
+
+// AutoComplete command turns a autoComplete command (usually a pipeline) into a []byte command.
+type AutoComplete struct {
+	Encoding AutoCompleteEncoding
+	Pipeline poc.AutoCompleteCaller
+}
+
+// CallBytes forwards parsed bytes to the AutoComplete command.
+func (a AutoComplete) CallBytes(ctx context.Context, b []byte) ([]byte, error) {
+	game, err := a.Encoding.DecodeAutoComplete(b)
+	if err != nil {
+		return nil, poc.Error{Actual: fmt.Errorf("could not decode request: %w", err), Category: poc.MalformedError}
+	}
+	game, err = a.Pipeline.CallAutoComplete(ctx, game)
+	if err != nil {
+		return nil, err
+	}
+	result, err := a.Encoding.EncodeAutoComplete(game)
+	if err != nil {
+		logger.Errorf(ctx, "could not encode auto complete response %#v: %s", game, err)
+		return nil, poc.Error{Actual: errors.New("could not encode response"), Category: poc.UnknownError}
+	}
+	return result, nil
+}
+
+// SolveGameEncoding may deserialize a solveGame input and serialize a
+// solveGame result.
+type SolveGameEncoding interface {
+	EncodeSolveGame(poc.SolveGame) ([]byte, error)
+	DecodeSolveGame([]byte) (poc.SolveGame, error)
+}
+
+// SolveGame command turns a solveGame command (usually a pipeline) into a []byte command.
+type SolveGame struct {
+	Encoding SolveGameEncoding
+	Pipeline poc.SolveGameCaller
+}
+
+// CallBytes forwards parsed bytes to the SolveGame command.
+func (s SolveGame) CallBytes(ctx context.Context, b []byte) ([]byte, error) {
+	game, err := s.Encoding.DecodeSolveGame(b)
+	if err != nil {
+		return nil, poc.Error{Actual: fmt.Errorf("could not decode request: %w", err), Category: poc.MalformedError}
+	}
+	game, err = s.Pipeline.CallSolveGame(ctx, game)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.Encoding.EncodeSolveGame(game)
+	if err != nil {
+		logger.Errorf(ctx, "could not encode solve game response %#v: %s", game, err)
+		return nil, poc.Error{Actual: errors.New("could not encode response"), Category: poc.UnknownError}
+	}
+	return result, nil
+}
+
+// HintEncoding may deserialize a hint input and serialize a
+// hint result.
+type HintEncoding interface {
+	EncodeHint(poc.Hint) ([]byte, error)
+	DecodeHint([]byte) (poc.Hint, error)
+}
+
+// Hint command turns a hint command (usually a pipeline) into a []byte command.
+type Hint struct {
+	Encoding HintEncoding
+	Pipeline poc.HintCaller
+}
+
+// CallBytes forwards parsed bytes to the Hint command.
+func (h Hint) CallBytes(ctx context.Context, b []byte) ([]byte, error) {
+	game, err := h.Encoding.DecodeHint(b)
+	if err != nil {
+		return nil, poc.Error{Actual: fmt.Errorf("could not decode request: %w", err), Category: poc.MalformedError}
+	}
+	game, err = h.Pipeline.CallHint(ctx, game)
+	if err != nil {
+		return nil, err
+	}
+	result, err := h.Encoding.EncodeHint(game)
+	if err != nil {
+		logger.Errorf(ctx, "could not encode hint response %#v: %s", game, err)
+		return nil, poc.Error{Actual: errors.New("could not encode response"), Category: poc.UnknownError}
+	}
+	return result, nil
+}
+
+// UndoMoveEncoding may deserialize a undoMove input and serialize a
+// undoMove result.
+type UndoMoveEncoding interface {
+	EncodeUndoMove(poc.UndoMove) ([]byte, error)
+	DecodeUndoMove([]byte) (poc.UndoMove, error)
+}
+
+// UndoMove command turns a undoMove command (usually a pipeline) into a []byte command.
+type UndoMove struct {
+	Encoding UndoMoveEncoding
+	Pipeline poc.UndoMoveCaller
+}
+
+// CallBytes forwards parsed bytes to the UndoMove command.
+func (u UndoMove) CallBytes(ctx context.Context, b []byte) ([]byte, error) {
+	game, err := u.Encoding.DecodeUndoMove(b)
+	if err != nil {
+		return nil, poc.Error{Actual: fmt.Errorf("could not decode request: %w", err), Category: poc.MalformedError}
+	}
+	game, err = u.Pipeline.CallUndoMove(ctx, game)
+	if err != nil {
+		return nil, err
+	}
+	result, err := u.Encoding.EncodeUndoMove(game)
+	if err != nil {
+		logger.Errorf(ctx, "could not encode undo move response %#v: %s", game, err)
+		return nil, poc.Error{Actual: errors.New("could not encode response"), Category: poc.UnknownError}
+	}
+	return result, nil
+}
+
+// RedoMoveEncoding may deserialize a redoMove input and serialize a
+// redoMove result.
+type RedoMoveEncoding interface {
+	EncodeRedoMove(poc.RedoMove) ([]byte, error)
+	DecodeRedoMove([]byte) (poc.RedoMove, error)
+}
+
+// RedoMove command turns a redoMove command (usually a pipeline) into a []byte command.
+type RedoMove struct {
+	Encoding RedoMoveEncoding
+	Pipeline poc.RedoMoveCaller
+}
+
+// CallBytes forwards parsed bytes to the RedoMove command.
+func (r RedoMove) CallBytes(ctx context.Context, b []byte) ([]byte, error) {
+	game, err := r.Encoding.DecodeRedoMove(b)
+	if err != nil {
+		return nil, poc.Error{Actual: fmt.Errorf("could not decode request: %w", err), Category: poc.MalformedError}
+	}
+	game, err = r.Pipeline.CallRedoMove(ctx, game)
+	if err != nil {
+		return nil, err
+	}
+	result, err := r.Encoding.EncodeRedoMove(game)
+	if err != nil {
+		logger.Errorf(ctx, "could not encode redo move response %#v: %s", game, err)
+		return nil, poc.Error{Actual: errors.New("could not encode response"), Category: poc.UnknownError}
+	}
+	return result, nil
+}
+
+// FairnessEncoding may deserialize a fairness input and serialize a
+// fairness result.
+type FairnessEncoding interface {
+	EncodeFairness(poc.Fairness) ([]byte, error)
+	DecodeFairness([]byte) (poc.Fairness, error)
+}
+
+// Fairness command turns a fairness command (usually a pipeline) into a []byte command.
+type Fairness struct {
+	Encoding FairnessEncoding
+	Pipeline poc.FairnessCaller
+}
+
+// CallBytes forwards parsed bytes to the Fairness command.
+func (f Fairness) CallBytes(ctx context.Context, b []byte) ([]byte, error) {
+	game, err := f.Encoding.DecodeFairness(b)
+	if err != nil {
+		return nil, poc.Error{Actual: fmt.Errorf("could not decode request: %w", err), Category: poc.MalformedError}
+	}
+	game, err = f.Pipeline.CallFairness(ctx, game)
+	if err != nil {
+		return nil, err
+	}
+	result, err := f.Encoding.EncodeFairness(game)
+	if err != nil {
+		logger.Errorf(ctx, "could not encode fairness response %#v: %s", game, err)
+		return nil, poc.Error{Actual: errors.New("could not encode response"), Category: poc.UnknownError}
+	}
+	return result, nil
+}
+
+// ReplayEncoding may deserialize a replay input and serialize a replay
+// result.
+type ReplayEncoding interface {
+	EncodeReplay(poc.Replay) ([]byte, error)
+	DecodeReplay([]byte) (poc.Replay, error)
+}
+
+// Replay command turns a replay command (usually a pipeline) into a []byte command.
+type Replay struct {
+	Encoding ReplayEncoding
+	Pipeline poc.ReplayCaller
+}
+
+// CallBytes forwards parsed bytes to the Replay command.
+func (r Replay) CallBytes(ctx context.Context, b []byte) ([]byte, error) {
+	game, err := r.Encoding.DecodeReplay(b)
+	if err != nil {
+		return nil, poc.Error{Actual: fmt.Errorf("could not decode request: %w", err), Category: poc.MalformedError}
+	}
+	game, err = r.Pipeline.CallReplay(ctx, game)
+	if err != nil {
+		return nil, err
+	}
+	result, err := r.Encoding.EncodeReplay(game)
+	if err != nil {
+		logger.Errorf(ctx, "could not encode replay response %#v: %s", game, err)
+		return nil, poc.Error{Actual: errors.New("could not encode response"), Category: poc.UnknownError}
+	}
+	return result, nil
+}
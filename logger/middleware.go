@@ -95,12 +95,21 @@ func BytesMiddleware(next ByteCaller) Middleware {
 
 // Values holds important logging context information.
 type Values struct {
-	Bytes       []byte
-	StartGame   poc.StartGame
-	PerformMove poc.PerformMove
-	ListGames   poc.ListGames
-	Message     string
-	Extra       []KeyValue
+	Bytes        []byte
+	StartGame    poc.StartGame
+	PerformMove  poc.PerformMove
+	LookupGame   poc.LookupGame
+	Fairness     poc.Fairness
+	Replay       poc.Replay
+	RedoMove     poc.RedoMove
+	UndoMove     poc.UndoMove
+	Hint         poc.Hint
+	SolveGame    poc.SolveGame
+	AutoComplete poc.AutoComplete
+	ResignGame   poc.ResignGame
+	ListGames    poc.ListGames
+	Message      string
+	Extra        []KeyValue
 }
 
 // MarshalJSON formats context for single-level logging values and masks PII.
@@ -118,6 +127,16 @@ func (v Values) MarshalJSON() ([]byte, error) {
 		StartGameVariantMaxTimesThroughDeck int32                  `json:"start_game_variant_max_times_through_deck,omitempty"`
 		PerformMoveGameID                   int64                  `json:"perform_move_game_id,omitempty"`
 		PerformMoveNumCardsToMove           int                    `json:"perform_move_num_cards_to_move,omitempty"`
+		LookupGameGameID                    int64                  `json:"lookup_game_game_id,omitempty"`
+		FairnessGameID                      int64                  `json:"fairness_game_id,omitempty"`
+		ReplayGameID                        int64                  `json:"replay_game_id,omitempty"`
+		ReplayMoveNumber                    int32                  `json:"replay_move_number,omitempty"`
+		RedoMoveGameID                      int64                  `json:"redo_move_game_id,omitempty"`
+		UndoMoveGameID                      int64                  `json:"undo_move_game_id,omitempty"`
+		HintGameID                          int64                  `json:"hint_game_id,omitempty"`
+		SolveGameGameID                     int64                  `json:"solve_game_game_id,omitempty"`
+		AutoCompleteGameID                  int64                  `json:"auto_complete_game_id,omitempty"`
+		ResignGameGameID                    int64                  `json:"resign_game_game_id,omitempty"`
 		ListGamesOffset                     int32                  `json:"list_games_offset,omitempty"`
 		ListGamesLimit                      int32                  `json:"list_games_limit,omitempty"`
 		Extra                               map[string]interface{} `json:"extra,omitempty"`
@@ -127,6 +146,16 @@ func (v Values) MarshalJSON() ([]byte, error) {
 		StartGameVariantMaxTimesThroughDeck: v.StartGame.Variant.MaxTimesThroughDeck,
 		PerformMoveGameID:                   v.PerformMove.SavedGameDetail.GameID,
 		PerformMoveNumCardsToMove:           len(v.PerformMove.Next),
+		LookupGameGameID:                    v.LookupGame.SavedGameDetail.GameID,
+		FairnessGameID:                      v.Fairness.SavedGameDetail.GameID,
+		ReplayGameID:                        v.Replay.SavedGameDetail.GameID,
+		ReplayMoveNumber:                    v.Replay.MoveNumber,
+		RedoMoveGameID:                      v.RedoMove.SavedGameDetail.GameID,
+		UndoMoveGameID:                      v.UndoMove.SavedGameDetail.GameID,
+		HintGameID:                          v.Hint.SavedGameDetail.GameID,
+		SolveGameGameID:                     v.SolveGame.SavedGameDetail.GameID,
+		AutoCompleteGameID:                  v.AutoComplete.SavedGameDetail.GameID,
+		ResignGameGameID:                    v.ResignGame.SavedGameDetail.GameID,
 		ListGamesOffset:                     v.ListGames.Cursor.Offset,
 		ListGamesLimit:                      v.ListGames.Cursor.Limit,
 		Extra:                               extra,
@@ -60,15 +60,127 @@ type PositionedCard struct {
 	Card     Card
 }
 
-// Board is the current state of the board.
+// Slot places a pile on the table in games whose cards overlap, such as
+// Pyramid. Rows run down the table and columns are counted in half card
+// widths so that overlapping rows can be staggered.
+type Slot struct {
+	PileNum int
+	Row     int
+	Column  int
+}
+
+// Cover means the cards of PileNum can't be played until pile CoveredBy is
+// empty.
+type Cover struct {
+	PileNum   int
+	CoveredBy int
+}
+
+// Board is the current state of the board. How many piles there are and
+// what each one is for depends on the game Family. Families whose cards
+// overlap lay their piles out in Slots.
 type Board struct {
-	Piles [13][]PositionedCard
-	Score int32
+	Piles  [][]PositionedCard
+	Slots  []Slot
+	Covers []Cover
+	Score  int32
 }
 
-// Variant is a rules variant.
+// Hash identifies the position of the cards on a Board. Boards with the same
+// cards in the same places have the same Hash.
+type Hash uint64
+
+// Family is a kind of solitaire game.
+//go:generate stringer -type=Family
+type Family uint8
+
+// Supported game families. The zero value is Klondike.
+const (
+	Klondike Family = iota
+	Spider
+	FreeCell
+	Pyramid
+	TriPeaks
+)
+
+// ColumnFill restricts which cards may be moved into an empty tableau pile.
+//go:generate stringer -type=ColumnFill
+type ColumnFill uint8
+
+// Supported column fill rules. The zero value is the standard Klondike rule.
+const (
+	KingsOnlyFill ColumnFill = iota
+	AnyCardFill
+	NoCardFill
+)
+
+// Scoring is a way of keeping score.
+//go:generate stringer -type=Scoring
+type Scoring uint8
+
+// Supported scoring rules. The zero value is standard (Windows) scoring.
+const (
+	StandardScoring Scoring = iota
+	VegasScoring
+)
+
+// GameStatus is whether a game is still being played.
+//go:generate stringer -type=GameStatus
+type GameStatus uint8
+
+// Possible game statuses. Every status other than InProgress is finished.
+const (
+	InProgress GameStatus = iota
+	Won
+	Stuck
+	Resigned
+)
+
+// Solvability is what a solver found out about a game.
+//go:generate stringer -type=Solvability
+type Solvability uint8
+
+// Possible solver results.
+const (
+	_ Solvability = iota
+	Winnable
+	Unwinnable
+	SearchLimitReached
+)
+
+// HintReason is why a move is worth making.
+//go:generate stringer -type=HintReason
+type HintReason uint8
+
+// Possible hint reasons.
+const (
+	_ HintReason = iota
+	ToFoundation
+	RevealCard
+	EmptyColumn
+	PlayTalon
+	BuildTableau
+	DrawStock
+	RecycleTalon
+	FromFoundation
+)
+
+// Variant is a rules variant. Name is the registered ruleset the game is
+// played by and defaults to the name of its Family. Suits is the number of
+// suits Spider is dealt with (1, 2 or 4) and Jokers is the number of wild
+// jokers added to a Klondike deck (0, 1 or 2).
 type Variant struct {
+	Name                string
+	Family              Family
+	Suits               int32
+	Jokers              int32
 	MaxTimesThroughDeck int32
+	EmptyColumnFill     ColumnFill
+	Scoring             Scoring
+	DrawCount           int32
+	HintPenalty         int32
+	AllowUndo           bool
+	UndoPenalty         int32
 }
 
 // Move is a transformation of the board.
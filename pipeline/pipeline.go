@@ -31,11 +31,12 @@ type PerformMoveMiddleware interface {
 
 // Use middleware to wrap each command.
 func (ppipe PerformMove) UseEach(middleware ...PerformMoveMiddleware) PerformMove {
-	result := make([]poc.PerformMoveCaller, len(ppipe))
+	result := make([]poc.PerformMoveCaller, 0, len(ppipe))
 	for _, step := range ppipe {
 		for _, mw := range middleware {
-			result = append(result, mw.PerformMoveUse(step))
+			step = mw.PerformMoveUse(step)
 		}
+		result = append(result, step)
 	}
 	return result
 }
@@ -63,11 +64,12 @@ type StartGameMiddleware interface {
 
 // Use middleware to wrap each command.
 func (spipe StartGame) UseEach(middleware ...StartGameMiddleware) StartGame {
-	result := make([]poc.StartGameCaller, len(spipe))
+	result := make([]poc.StartGameCaller, 0, len(spipe))
 	for _, step := range spipe {
 		for _, mw := range middleware {
-			result = append(result, mw.StartGameUse(step))
+			step = mw.StartGameUse(step)
 		}
+		result = append(result, step)
 	}
 	return result
 }
@@ -95,12 +97,309 @@ type ListGamesMiddleware interface {
 
 // Use middleware to wrap each command.
 func (lpipe ListGames) UseEach(middleware ...ListGamesMiddleware) ListGames {
-	result := make([]poc.ListGamesCaller, len(lpipe))
+	result := make([]poc.ListGamesCaller, 0, len(lpipe))
 	for _, step := range lpipe {
 		for _, mw := range middleware {
-			result = append(result, mw.ListGamesUse(step))
+			step = mw.ListGamesUse(step)
 		}
+		result = append(result, step)
 	}
 	return result
 }
 
+// LookupGame uses the same context for every command, but uses the
+// lookupGame output from the previous command as input to the next command.
+type LookupGame []poc.LookupGameCaller
+
+// CallLookupGame exits early at the first command that returns an error.
+func (gpipe LookupGame) CallLookupGame(ctx context.Context, g poc.LookupGame) (poc.LookupGame, error) {
+	var err error
+
+	for _, step := range gpipe {
+		g, err = step.CallLookupGame(ctx, g)
+		if err != nil {
+			return g, err
+		}
+	}
+	return g, nil
+}
+
+type LookupGameMiddleware interface {
+	LookupGameUse(poc.LookupGameCaller) poc.LookupGameCaller
+}
+
+// Use middleware to wrap each command.
+func (gpipe LookupGame) UseEach(middleware ...LookupGameMiddleware) LookupGame {
+	result := make([]poc.LookupGameCaller, 0, len(gpipe))
+	for _, step := range gpipe {
+		for _, mw := range middleware {
+			step = mw.LookupGameUse(step)
+		}
+		result = append(result, step)
+	}
+	return result
+}
+
+// ResignGame uses the same context for every command, but uses the
+// resignGame output from the previous command as input to the next command.
+type ResignGame []poc.ResignGameCaller
+
+// CallResignGame exits early at the first command that returns an error.
+func (gpipe ResignGame) CallResignGame(ctx context.Context, g poc.ResignGame) (poc.ResignGame, error) {
+	var err error
+
+	for _, step := range gpipe {
+		g, err = step.CallResignGame(ctx, g)
+		if err != nil {
+			return g, err
+		}
+	}
+	return g, nil
+}
+
+type ResignGameMiddleware interface {
+	ResignGameUse(poc.ResignGameCaller) poc.ResignGameCaller
+}
+
+// Use middleware to wrap each command.
+func (gpipe ResignGame) UseEach(middleware ...ResignGameMiddleware) ResignGame {
+	result := make([]poc.ResignGameCaller, 0, len(gpipe))
+	for _, step := range gpipe {
+		for _, mw := range middleware {
+			step = mw.ResignGameUse(step)
+		}
+		result = append(result, step)
+	}
+	return result
+}
+
+// AutoComplete uses the same context for every command, but uses the
+// autoComplete output from the previous command as input to the next command.
+type AutoComplete []poc.AutoCompleteCaller
+
+// CallAutoComplete exits early at the first command that returns an error.
+func (gpipe AutoComplete) CallAutoComplete(ctx context.Context, g poc.AutoComplete) (poc.AutoComplete, error) {
+	var err error
+
+	for _, step := range gpipe {
+		g, err = step.CallAutoComplete(ctx, g)
+		if err != nil {
+			return g, err
+		}
+	}
+	return g, nil
+}
+
+type AutoCompleteMiddleware interface {
+	AutoCompleteUse(poc.AutoCompleteCaller) poc.AutoCompleteCaller
+}
+
+// Use middleware to wrap each command.
+func (gpipe AutoComplete) UseEach(middleware ...AutoCompleteMiddleware) AutoComplete {
+	result := make([]poc.AutoCompleteCaller, 0, len(gpipe))
+	for _, step := range gpipe {
+		for _, mw := range middleware {
+			step = mw.AutoCompleteUse(step)
+		}
+		result = append(result, step)
+	}
+	return result
+}
+
+// SolveGame uses the same context for every command, but uses the
+// solveGame output from the previous command as input to the next command.
+type SolveGame []poc.SolveGameCaller
+
+// CallSolveGame exits early at the first command that returns an error.
+func (gpipe SolveGame) CallSolveGame(ctx context.Context, g poc.SolveGame) (poc.SolveGame, error) {
+	var err error
+
+	for _, step := range gpipe {
+		g, err = step.CallSolveGame(ctx, g)
+		if err != nil {
+			return g, err
+		}
+	}
+	return g, nil
+}
+
+type SolveGameMiddleware interface {
+	SolveGameUse(poc.SolveGameCaller) poc.SolveGameCaller
+}
+
+// Use middleware to wrap each command.
+func (gpipe SolveGame) UseEach(middleware ...SolveGameMiddleware) SolveGame {
+	result := make([]poc.SolveGameCaller, 0, len(gpipe))
+	for _, step := range gpipe {
+		for _, mw := range middleware {
+			step = mw.SolveGameUse(step)
+		}
+		result = append(result, step)
+	}
+	return result
+}
+
+// Hint uses the same context for every command, but uses the
+// hint output from the previous command as input to the next command.
+type Hint []poc.HintCaller
+
+// CallHint exits early at the first command that returns an error.
+func (gpipe Hint) CallHint(ctx context.Context, g poc.Hint) (poc.Hint, error) {
+	var err error
+
+	for _, step := range gpipe {
+		g, err = step.CallHint(ctx, g)
+		if err != nil {
+			return g, err
+		}
+	}
+	return g, nil
+}
+
+type HintMiddleware interface {
+	HintUse(poc.HintCaller) poc.HintCaller
+}
+
+// Use middleware to wrap each command.
+func (gpipe Hint) UseEach(middleware ...HintMiddleware) Hint {
+	result := make([]poc.HintCaller, 0, len(gpipe))
+	for _, step := range gpipe {
+		for _, mw := range middleware {
+			step = mw.HintUse(step)
+		}
+		result = append(result, step)
+	}
+	return result
+}
+
+// UndoMove uses the same context for every command, but uses the
+// undoMove output from the previous command as input to the next command.
+type UndoMove []poc.UndoMoveCaller
+
+// CallUndoMove exits early at the first command that returns an error.
+func (gpipe UndoMove) CallUndoMove(ctx context.Context, g poc.UndoMove) (poc.UndoMove, error) {
+	var err error
+
+	for _, step := range gpipe {
+		g, err = step.CallUndoMove(ctx, g)
+		if err != nil {
+			return g, err
+		}
+	}
+	return g, nil
+}
+
+type UndoMoveMiddleware interface {
+	UndoMoveUse(poc.UndoMoveCaller) poc.UndoMoveCaller
+}
+
+// Use middleware to wrap each command.
+func (gpipe UndoMove) UseEach(middleware ...UndoMoveMiddleware) UndoMove {
+	result := make([]poc.UndoMoveCaller, 0, len(gpipe))
+	for _, step := range gpipe {
+		for _, mw := range middleware {
+			step = mw.UndoMoveUse(step)
+		}
+		result = append(result, step)
+	}
+	return result
+}
+
+// RedoMove uses the same context for every command, but uses the
+// redoMove output from the previous command as input to the next command.
+type RedoMove []poc.RedoMoveCaller
+
+// CallRedoMove exits early at the first command that returns an error.
+func (gpipe RedoMove) CallRedoMove(ctx context.Context, g poc.RedoMove) (poc.RedoMove, error) {
+	var err error
+
+	for _, step := range gpipe {
+		g, err = step.CallRedoMove(ctx, g)
+		if err != nil {
+			return g, err
+		}
+	}
+	return g, nil
+}
+
+type RedoMoveMiddleware interface {
+	RedoMoveUse(poc.RedoMoveCaller) poc.RedoMoveCaller
+}
+
+// Use middleware to wrap each command.
+func (gpipe RedoMove) UseEach(middleware ...RedoMoveMiddleware) RedoMove {
+	result := make([]poc.RedoMoveCaller, 0, len(gpipe))
+	for _, step := range gpipe {
+		for _, mw := range middleware {
+			step = mw.RedoMoveUse(step)
+		}
+		result = append(result, step)
+	}
+	return result
+}
+
+// Fairness uses the same context for every command, but uses the
+// fairness output from the previous command as input to the next command.
+type Fairness []poc.FairnessCaller
+
+// CallFairness exits early at the first command that returns an error.
+func (gpipe Fairness) CallFairness(ctx context.Context, g poc.Fairness) (poc.Fairness, error) {
+	var err error
+
+	for _, step := range gpipe {
+		g, err = step.CallFairness(ctx, g)
+		if err != nil {
+			return g, err
+		}
+	}
+	return g, nil
+}
+
+type FairnessMiddleware interface {
+	FairnessUse(poc.FairnessCaller) poc.FairnessCaller
+}
+
+// Use middleware to wrap each command.
+func (gpipe Fairness) UseEach(middleware ...FairnessMiddleware) Fairness {
+	result := make([]poc.FairnessCaller, 0, len(gpipe))
+	for _, step := range gpipe {
+		for _, mw := range middleware {
+			step = mw.FairnessUse(step)
+		}
+		result = append(result, step)
+	}
+	return result
+}
+
+// Replay uses the same context for every command, but uses the
+// replay output from the previous command as input to the next command.
+type Replay []poc.ReplayCaller
+
+// CallReplay exits early at the first command that returns an error.
+func (gpipe Replay) CallReplay(ctx context.Context, g poc.Replay) (poc.Replay, error) {
+	var err error
+
+	for _, step := range gpipe {
+		g, err = step.CallReplay(ctx, g)
+		if err != nil {
+			return g, err
+		}
+	}
+	return g, nil
+}
+
+type ReplayMiddleware interface {
+	ReplayUse(poc.ReplayCaller) poc.ReplayCaller
+}
+
+// Use middleware to wrap each command.
+func (gpipe Replay) UseEach(middleware ...ReplayMiddleware) Replay {
+	result := make([]poc.ReplayCaller, 0, len(gpipe))
+	for _, step := range gpipe {
+		for _, mw := range middleware {
+			step = mw.ReplayUse(step)
+		}
+		result = append(result, step)
+	}
+	return result
+}
@@ -30,7 +30,8 @@ type key string
 const query = key("query")
 
 const (
-	gameIDKey = "gameID"
+	gameIDKey     = "gameID"
+	moveNumberKey = "moveNumber"
 )
 
 //go:generate go run github.com/golang/mock/mockgen -package=mocks -destination=../test/mocks/router.go -source=router.go
@@ -42,9 +43,18 @@ type ByteCaller interface {
 
 // V1Handlers members must not be nil.
 type V1Handlers struct {
-	PostGameStart    ByteCaller
-	PostGameByIDMove ByteCaller
-	GetGameList      ByteCaller
+	PostGameStart            ByteCaller
+	PostGameByIDMove         ByteCaller
+	GetGameList              ByteCaller
+	GetGameByID              ByteCaller
+	PostGameByIDResign       ByteCaller
+	PostGameByIDAutoComplete ByteCaller
+	PostGameByIDSolve        ByteCaller
+	PostGameByIDHint         ByteCaller
+	PostGameByIDUndo         ByteCaller
+	PostGameByIDRedo         ByteCaller
+	GetGameByIDFairness      ByteCaller
+	GetGameByIDAtMoveNumber  ByteCaller
 }
 
 // New sets up routes with passed middleware.
@@ -56,6 +66,15 @@ func New(
 	router.Post("/v1/game/start", handlerFunc(v1.PostGameStart))
 	router.Post(fmt.Sprintf("/v1/game/{%s}/move", gameIDKey), handlerFunc(v1.PostGameByIDMove))
 	router.Get("/v1/game/list", handlerFunc(v1.GetGameList))
+	router.Get(fmt.Sprintf("/v1/game/{%s}", gameIDKey), handlerFunc(v1.GetGameByID))
+	router.Post(fmt.Sprintf("/v1/game/{%s}/resign", gameIDKey), handlerFunc(v1.PostGameByIDResign))
+	router.Post(fmt.Sprintf("/v1/game/{%s}/autocomplete", gameIDKey), handlerFunc(v1.PostGameByIDAutoComplete))
+	router.Post(fmt.Sprintf("/v1/game/{%s}/solve", gameIDKey), handlerFunc(v1.PostGameByIDSolve))
+	router.Post(fmt.Sprintf("/v1/game/{%s}/hint", gameIDKey), handlerFunc(v1.PostGameByIDHint))
+	router.Post(fmt.Sprintf("/v1/game/{%s}/undo", gameIDKey), handlerFunc(v1.PostGameByIDUndo))
+	router.Post(fmt.Sprintf("/v1/game/{%s}/redo", gameIDKey), handlerFunc(v1.PostGameByIDRedo))
+	router.Get(fmt.Sprintf("/v1/game/{%s}/fairness", gameIDKey), handlerFunc(v1.GetGameByIDFairness))
+	router.Get(fmt.Sprintf("/v1/game/{%s}/at/{%s}", gameIDKey, moveNumberKey), handlerFunc(v1.GetGameByIDAtMoveNumber))
 	return router
 }
 
@@ -72,6 +91,8 @@ func handlerFunc(f ByteCaller) http.HandlerFunc {
 					poc.UnimplementedError: http.StatusNotImplemented,
 					poc.NotFoundError:      http.StatusNotFound,
 					poc.UnknownError:       http.StatusInternalServerError,
+					poc.ConflictError:      http.StatusConflict,
+					poc.IntegrityError:     http.StatusInternalServerError,
 				}[catErr.Category]
 				if status == 0 {
 					status = http.StatusInternalServerError
@@ -101,6 +122,7 @@ func handlerFunc(f ByteCaller) http.HandlerFunc {
 type V1HydrateURLAndQueryParams struct {
 	OffsetKey string
 	LimitKey  string
+	StreamKey string
 }
 
 // CallPerformMove adds move.Input.GameID url path param.
@@ -114,6 +136,118 @@ func (params V1HydrateURLAndQueryParams) CallPerformMove(ctx context.Context, mo
 	return move, nil
 }
 
+// CallLookupGame adds game.SavedGameDetail.GameID url path param.
+func (params V1HydrateURLAndQueryParams) CallLookupGame(ctx context.Context, game poc.LookupGame) (poc.LookupGame, error) {
+	gameID := chi.URLParamFromCtx(ctx, gameIDKey)
+	var err error
+	game.SavedGameDetail.GameID, err = strconv.ParseInt(gameID, 10, 64)
+	if err != nil {
+		return game, poc.Error{Actual: err, Category: poc.MalformedError}
+	}
+	return game, nil
+}
+
+// CallFairness adds game.SavedGameDetail.GameID url path param.
+func (params V1HydrateURLAndQueryParams) CallFairness(ctx context.Context, game poc.Fairness) (poc.Fairness, error) {
+	gameID := chi.URLParamFromCtx(ctx, gameIDKey)
+	var err error
+	game.SavedGameDetail.GameID, err = strconv.ParseInt(gameID, 10, 64)
+	if err != nil {
+		return game, poc.Error{Actual: err, Category: poc.MalformedError}
+	}
+	return game, nil
+}
+
+// CallReplay adds game.SavedGameDetail.GameID and game.MoveNumber url path
+// params and the optional game.Stream url query param.
+func (params V1HydrateURLAndQueryParams) CallReplay(ctx context.Context, game poc.Replay) (poc.Replay, error) {
+	gameID := chi.URLParamFromCtx(ctx, gameIDKey)
+	var err error
+	game.SavedGameDetail.GameID, err = strconv.ParseInt(gameID, 10, 64)
+	if err != nil {
+		return game, poc.Error{Actual: err, Category: poc.MalformedError}
+	}
+	moveNumber, err := strconv.ParseInt(chi.URLParamFromCtx(ctx, moveNumberKey), 10, 32)
+	if err != nil {
+		return game, poc.Error{Actual: err, Category: poc.MalformedError}
+	}
+	game.MoveNumber = int32(moveNumber)
+	values := ctx.Value(query).(url.Values)
+	if stream := values.Get(params.StreamKey); stream != "" {
+		game.Stream, err = strconv.ParseBool(stream)
+		if err != nil {
+			return game, poc.Error{Actual: fmt.Errorf("parsing url param %#v: %w", params.StreamKey, err), Category: poc.MalformedError}
+		}
+	}
+	return game, nil
+}
+
+// CallRedoMove adds game.SavedGameDetail.GameID url path param.
+func (params V1HydrateURLAndQueryParams) CallRedoMove(ctx context.Context, game poc.RedoMove) (poc.RedoMove, error) {
+	gameID := chi.URLParamFromCtx(ctx, gameIDKey)
+	var err error
+	game.SavedGameDetail.GameID, err = strconv.ParseInt(gameID, 10, 64)
+	if err != nil {
+		return game, poc.Error{Actual: err, Category: poc.MalformedError}
+	}
+	return game, nil
+}
+
+// CallUndoMove adds game.SavedGameDetail.GameID url path param.
+func (params V1HydrateURLAndQueryParams) CallUndoMove(ctx context.Context, game poc.UndoMove) (poc.UndoMove, error) {
+	gameID := chi.URLParamFromCtx(ctx, gameIDKey)
+	var err error
+	game.SavedGameDetail.GameID, err = strconv.ParseInt(gameID, 10, 64)
+	if err != nil {
+		return game, poc.Error{Actual: err, Category: poc.MalformedError}
+	}
+	return game, nil
+}
+
+// CallHint adds game.SavedGameDetail.GameID url path param.
+func (params V1HydrateURLAndQueryParams) CallHint(ctx context.Context, game poc.Hint) (poc.Hint, error) {
+	gameID := chi.URLParamFromCtx(ctx, gameIDKey)
+	var err error
+	game.SavedGameDetail.GameID, err = strconv.ParseInt(gameID, 10, 64)
+	if err != nil {
+		return game, poc.Error{Actual: err, Category: poc.MalformedError}
+	}
+	return game, nil
+}
+
+// CallSolveGame adds game.SavedGameDetail.GameID url path param.
+func (params V1HydrateURLAndQueryParams) CallSolveGame(ctx context.Context, game poc.SolveGame) (poc.SolveGame, error) {
+	gameID := chi.URLParamFromCtx(ctx, gameIDKey)
+	var err error
+	game.SavedGameDetail.GameID, err = strconv.ParseInt(gameID, 10, 64)
+	if err != nil {
+		return game, poc.Error{Actual: err, Category: poc.MalformedError}
+	}
+	return game, nil
+}
+
+// CallAutoComplete adds game.SavedGameDetail.GameID url path param.
+func (params V1HydrateURLAndQueryParams) CallAutoComplete(ctx context.Context, game poc.AutoComplete) (poc.AutoComplete, error) {
+	gameID := chi.URLParamFromCtx(ctx, gameIDKey)
+	var err error
+	game.SavedGameDetail.GameID, err = strconv.ParseInt(gameID, 10, 64)
+	if err != nil {
+		return game, poc.Error{Actual: err, Category: poc.MalformedError}
+	}
+	return game, nil
+}
+
+// CallResignGame adds game.SavedGameDetail.GameID url path param.
+func (params V1HydrateURLAndQueryParams) CallResignGame(ctx context.Context, game poc.ResignGame) (poc.ResignGame, error) {
+	gameID := chi.URLParamFromCtx(ctx, gameIDKey)
+	var err error
+	game.SavedGameDetail.GameID, err = strconv.ParseInt(gameID, 10, 64)
+	if err != nil {
+		return game, poc.Error{Actual: err, Category: poc.MalformedError}
+	}
+	return game, nil
+}
+
 // CallListGames adds list.Input.Limit and list.Input.Offset url query param.
 func (params V1HydrateURLAndQueryParams) CallListGames(ctx context.Context, cursor poc.ListGames) (poc.ListGames, error) {
 	values := ctx.Value(query).(url.Values)
@@ -23,6 +23,15 @@ func TestRouter(t *testing.T) {
 		{http.MethodPost, "/v1/game/start"},
 		{http.MethodPost, "/v1/game/2021/move"},
 		{http.MethodGet, "/v1/game/list"},
+		{http.MethodGet, "/v1/game/2021"},
+		{http.MethodPost, "/v1/game/2021/resign"},
+		{http.MethodPost, "/v1/game/2021/autocomplete"},
+		{http.MethodPost, "/v1/game/2021/solve"},
+		{http.MethodPost, "/v1/game/2021/hint"},
+		{http.MethodPost, "/v1/game/2021/undo"},
+		{http.MethodPost, "/v1/game/2021/redo"},
+		{http.MethodGet, "/v1/game/2021/fairness"},
+		{http.MethodGet, "/v1/game/2021/at/3"},
 	} {
 		for _, testCase := range []struct {
 			Error poc.ErrorCategory
@@ -34,6 +43,8 @@ func TestRouter(t *testing.T) {
 			{poc.UnimplementedError, http.StatusNotImplemented},
 			{poc.NotFoundError, http.StatusNotFound},
 			{poc.UnknownError, http.StatusInternalServerError},
+			{poc.ConflictError, http.StatusConflict},
+			{poc.IntegrityError, http.StatusInternalServerError},
 		} {
 			t.Run(fmt.Sprintf("%s %v %s %d", client.Method, client.Path, testCase.Error, testCase.Code), checkSaveErrorCode(client.Method, client.Path, testCase.Error, testCase.Code))
 		}
@@ -46,9 +57,18 @@ func checkSaveErrorCode(method string, path string, category poc.ErrorCategory,
 		ctrl := gomock.NewController(t)
 		command := mocks.NewMockByteCaller(ctrl)
 		mux := router.New(router.V1Handlers{
-			PostGameStart:    command,
-			PostGameByIDMove: command,
-			GetGameList:      command,
+			PostGameStart:            command,
+			PostGameByIDMove:         command,
+			GetGameList:              command,
+			GetGameByID:              command,
+			PostGameByIDResign:       command,
+			PostGameByIDAutoComplete: command,
+			PostGameByIDSolve:        command,
+			PostGameByIDHint:         command,
+			PostGameByIDUndo:         command,
+			PostGameByIDRedo:         command,
+			GetGameByIDFairness:      command,
+			GetGameByIDAtMoveNumber:  command,
 		})
 		command.
 			EXPECT().
@@ -0,0 +1,75 @@
+package rules
+
+import (
+	"context"
+	"errors"
+
+	"github.com/slcjordan/poc"
+)
+
+// ErrNotAutoCompletable means the game still needs a player to finish it.
+var ErrNotAutoCompletable = errors.New("game can not be auto completed")
+
+// autoCompletable reports whether the game can be finished by only moving
+// tableau cards to the foundations: the stock and talon are empty and every
+// tableau card is face up.
+func autoCompletable(game poc.SavedGameDetail) bool {
+	if len(game.Board.Piles[0]) > 0 || len(game.Board.Piles[1]) > 0 {
+		return false
+	}
+	for _, pile := range game.Board.Piles[2:9] {
+		for _, card := range pile {
+			if card.Position&poc.FaceUp == 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// foundationMove finds a move of a tableau card onto its foundation.
+func foundationMove(game poc.SavedGameDetail) ([]poc.Move, bool) {
+	for _, moves := range nextMoves(game) {
+		if len(moves) == 1 && isTableau(moves[0].OldPileNum) && isFoundation(moves[0].NewPileNum) {
+			return moves, true
+		}
+	}
+	return nil, false
+}
+
+// AutoComplete plays out a game that no longer needs a player.
+type AutoComplete struct{}
+
+// CallAutoComplete moves tableau cards to the foundations one at a time until
+// the game is won. Each move is validated, applied and scored just like a
+// move performed by the player, and is added to game.Moves. A game that can
+// be auto completed can't get stuck, so no search is made for it.
+func (a AutoComplete) CallAutoComplete(ctx context.Context, game poc.AutoComplete) (poc.AutoComplete, error) {
+	if game.SavedGameDetail.Variant.Family != poc.Klondike {
+		return game, poc.Error{Actual: ErrKlondikeOnly, Category: poc.UnimplementedError}
+	}
+	if game.SavedGameDetail.Status != poc.InProgress {
+		return game, poc.Error{Actual: ErrGameOver, Category: poc.SemanticError}
+	}
+	if !autoCompletable(game.SavedGameDetail) {
+		return game, poc.Error{Actual: ErrNotAutoCompletable, Category: poc.SemanticError}
+	}
+	steps := []poc.PerformMoveCaller{Validate{}, Apply{}, Score{}, Status{MaxPositions: 1}}
+	for game.SavedGameDetail.Status == poc.InProgress {
+		next, ok := foundationMove(game.SavedGameDetail)
+		if !ok {
+			return game, poc.Error{Actual: ErrNotAutoCompletable, Category: poc.SemanticError}
+		}
+		move := poc.PerformMove{Next: next, SavedGameDetail: game.SavedGameDetail}
+		var err error
+		for _, step := range steps {
+			move, err = step.CallPerformMove(ctx, move)
+			if err != nil {
+				return game, err
+			}
+		}
+		game.SavedGameDetail = move.SavedGameDetail
+		game.Moves = append(game.Moves, next)
+	}
+	return game, nil
+}
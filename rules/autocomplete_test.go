@@ -0,0 +1,82 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/slcjordan/poc"
+	"github.com/slcjordan/poc/rules"
+	"github.com/slcjordan/poc/test/assert"
+	"github.com/slcjordan/poc/test/harness"
+	"github.com/slcjordan/poc/test/logger"
+)
+
+func TestAutoComplete(t *testing.T) {
+	logger.RegisterVerbose(t)
+	board := klondike() // everything but the hearts and the king of spades is home
+	for suit := poc.Clubs; suit <= poc.Spades; suit++ {
+		for index := poc.Ace; index <= poc.King; index++ {
+			if suit == poc.Spades && index == poc.King {
+				continue
+			}
+			board.Piles[suit+8] = append(board.Piles[suit+8], poc.PositionedCard{
+				Position: poc.FaceUp,
+				Card:     poc.Card{Suit: suit, Index: index},
+			})
+		}
+	}
+	board.Piles[2] = []poc.PositionedCard{{Position: poc.FaceUp, Card: poc.Card{Suit: poc.Spades, Index: poc.King}}}
+	for index := poc.King; index >= poc.Ace; index-- {
+		board.Piles[3+int(index)%2] = append(board.Piles[3+int(index)%2], poc.PositionedCard{
+			Position: poc.FaceUp,
+			Card:     poc.Card{Suit: poc.Hearts, Index: index},
+		})
+	}
+	stock := clone(board)
+	stock.Piles[0] = stock.Piles[2]
+	stock.Piles[2] = nil
+	faceDown := clone(board)
+	faceDown.Piles[2] = []poc.PositionedCard{{Card: poc.Card{Suit: poc.Spades, Index: poc.King}}}
+
+	harness.AutoComplete{
+		{
+			Desc:    "Finishes the game",
+			Command: rules.AutoComplete{},
+			Input: poc.AutoComplete{
+				SavedGameDetail: poc.SavedGameDetail{Board: board},
+			},
+			Result: func() *assert.Assertion {
+				a := assert.New().NoError()
+				a.AutoComplete.Moves.Length(assert.Equals(14))
+				a.AutoComplete.SavedGameDetail.History.Length(assert.Equals(14))
+				a.AutoComplete.SavedGameDetail.Status.Uint8(assert.Equals(poc.Won))
+				a.AutoComplete.SavedGameDetail.Board.Score(assert.Equals(140))
+				a.AutoComplete.SavedGameDetail.Board.Piles.Nth(9).Length(assert.Equals(13))
+				return a
+			}(),
+		},
+		{
+			Desc:    "Cards left in the stock",
+			Command: rules.AutoComplete{},
+			Input: poc.AutoComplete{
+				SavedGameDetail: poc.SavedGameDetail{Board: stock},
+			},
+			Result: assert.New().Error.Category.Uint8(assert.Equals(poc.SemanticError)),
+		},
+		{
+			Desc:    "Face down tableau card",
+			Command: rules.AutoComplete{},
+			Input: poc.AutoComplete{
+				SavedGameDetail: poc.SavedGameDetail{Board: faceDown},
+			},
+			Result: assert.New().Error.Category.Uint8(assert.Equals(poc.SemanticError)),
+		},
+		{
+			Desc:    "Finished game",
+			Command: rules.AutoComplete{},
+			Input: poc.AutoComplete{
+				SavedGameDetail: poc.SavedGameDetail{Board: board, Status: poc.Resigned},
+			},
+			Result: assert.New().Error.Category.Uint8(assert.Equals(poc.SemanticError)),
+		},
+	}.Run(t)
+}
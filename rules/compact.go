@@ -0,0 +1,263 @@
+package rules
+
+import (
+	"github.com/slcjordan/poc"
+)
+
+// packed is a positioned card in a byte: the index in the low four bits, the
+// suit in the next three and the top bit set when the card is face up.
+type packed uint8
+
+const packedFaceUp packed = 0x80
+
+// pack packs a card. Cards with positions other than face up or down don't fit.
+func pack(card poc.PositionedCard) (packed, bool) {
+	if card.Card.Index > 0x0f || card.Card.Suit > 0x07 || card.Position&^poc.FaceUp != 0 {
+		return 0, false
+	}
+	result := packed(card.Card.Index) | packed(card.Card.Suit)<<4
+	if card.Position&poc.FaceUp != 0 {
+		result |= packedFaceUp
+	}
+	return result, true
+}
+
+func (p packed) unpack() poc.PositionedCard {
+	var position poc.Position
+	if p.faceUp() {
+		position = poc.FaceUp
+	}
+	return poc.PositionedCard{Position: position, Card: poc.Card{Suit: p.suit(), Index: p.index()}}
+}
+
+func (p packed) index() poc.Index {
+	return poc.Index(p & 0x0f)
+}
+
+func (p packed) suit() poc.Suit {
+	return poc.Suit(p >> 4 & 0x07)
+}
+
+func (p packed) faceUp() bool {
+	return p&packedFaceUp != 0
+}
+
+func (p packed) joker() bool {
+	return p.suit() == poc.Joker
+}
+
+func (p packed) red() bool {
+	return p.suit() == poc.Hearts || p.suit() == poc.Diamonds
+}
+
+// compactCards is the most cards a pile can hold: a full deck and both jokers.
+const compactCards = 54
+
+// compact is a Klondike board in fixed size arrays, for searches that look at
+// many positions. Boards convert to and from poc.Board without losing
+// anything but the difference between empty and nil piles. Only the tests use
+// it for now: Solve and goingNowhere still search poc.Board, and the tests
+// keep it listing the same moves as klondikeMoves until they move onto it.
+type compact struct {
+	piles [klondikePiles][compactCards]packed
+	sizes [klondikePiles]uint8
+	score int32
+}
+
+// toCompact packs a Klondike board.
+func toCompact(board poc.Board) (compact, bool) {
+	var result compact
+	if len(board.Piles) != klondikePiles || len(board.Slots) > 0 || len(board.Covers) > 0 {
+		return result, false
+	}
+	for pileNum, pile := range board.Piles {
+		if len(pile) > compactCards {
+			return result, false
+		}
+		for pileIndex, card := range pile {
+			p, ok := pack(card)
+			if !ok {
+				return result, false
+			}
+			result.piles[pileNum][pileIndex] = p
+		}
+		result.sizes[pileNum] = uint8(len(pile))
+	}
+	result.score = board.Score
+	return result, true
+}
+
+func (c *compact) top(pileNum int) packed {
+	return c.piles[pileNum][c.sizes[pileNum]-1]
+}
+
+// compactKind is a kind of compact move.
+type compactKind uint8
+
+// Kinds of compact moves.
+const (
+	compactFlip    compactKind = iota // turn over the top card of a pile
+	compactShift                      // move the cards from index up onto another pile
+	compactDraw                       // draw count cards from the stock onto the talon
+	compactRecycle                    // turn the talon over onto the stock
+)
+
+// compactMove is a Klondike move group on a compact board.
+type compactMove struct {
+	kind  compactKind
+	from  uint8
+	to    uint8
+	index uint8
+	count uint8
+}
+
+// moves appends the moves of a standard Klondike game to buf, in the same way
+// as klondikeMoves. Jokers are never moved: their moves are only listed on
+// poc.Board. recycle says whether the talon may still be turned over.
+func (c *compact) moves(variant poc.Variant, recycle bool, buf []compactMove) []compactMove {
+	result := buf[:0]
+	// takes reports whether card may be built onto tableau pile dest.
+	takes := func(card packed, dest int) bool {
+		if c.sizes[dest] < 1 {
+			switch variant.EmptyColumnFill {
+			case poc.AnyCardFill:
+				return true
+			case poc.KingsOnlyFill:
+				return card.index() == poc.King
+			}
+			return false
+		}
+		top := c.top(dest)
+		return top.faceUp() && !top.joker() && top.index() == card.index()+1 && top.red() != card.red()
+	}
+	// toTableau moves the cards of a pile from pileIndex up onto every tableau
+	// pile that takes them.
+	toTableau := func(pileNum int, pileIndex int) {
+		card := c.piles[pileNum][pileIndex]
+		if card.joker() {
+			return
+		}
+		whole := isTableau(pileNum) && pileIndex == 0
+		for dest := 2; dest < 9; dest++ {
+			if dest != pileNum && takes(card, dest) && !(whole && c.sizes[dest] < 1) {
+				result = append(result, compactMove{
+					kind:  compactShift,
+					from:  uint8(pileNum),
+					to:    uint8(dest),
+					index: uint8(pileIndex),
+					count: c.sizes[pileNum] - uint8(pileIndex),
+				})
+			}
+		}
+	}
+	// toFoundation moves the top card of a pile onto its foundation.
+	toFoundation := func(pileNum int) {
+		card := c.top(pileNum)
+		if card.joker() {
+			return
+		}
+		dest := int(card.suit()) + 8
+		if poc.Index(c.sizes[dest])+poc.Ace == card.index() {
+			result = append(result, compactMove{
+				kind:  compactShift,
+				from:  uint8(pileNum),
+				to:    uint8(dest),
+				index: c.sizes[pileNum] - 1,
+				count: 1,
+			})
+		}
+	}
+
+	for pileNum := 2; pileNum < 9; pileNum++ {
+		size := int(c.sizes[pileNum])
+		if size < 1 {
+			continue
+		}
+		if c.top(pileNum).faceUp() {
+			toFoundation(pileNum)
+		} else {
+			result = append(result, compactMove{kind: compactFlip, from: uint8(pileNum), to: uint8(pileNum), index: uint8(size - 1), count: 1})
+		}
+		for pileIndex := 0; pileIndex < size; pileIndex++ {
+			if c.piles[pileNum][pileIndex].faceUp() {
+				toTableau(pileNum, pileIndex)
+			}
+		}
+	}
+	if c.sizes[1] > 0 {
+		toTableau(1, int(c.sizes[1])-1)
+		toFoundation(1)
+	}
+	for pileNum := 9; pileNum < klondikePiles; pileNum++ {
+		if c.sizes[pileNum] > 0 {
+			toTableau(pileNum, int(c.sizes[pileNum])-1)
+		}
+	}
+	if stock := c.sizes[0]; stock > 0 {
+		drawCount := stock
+		if variant.DrawCount < 2 {
+			drawCount = 1
+		} else if variant.DrawCount < int32(stock) {
+			drawCount = uint8(variant.DrawCount)
+		}
+		result = append(result, compactMove{kind: compactDraw, from: 0, to: 1, index: stock - drawCount, count: drawCount})
+	} else if c.sizes[1] > 0 && recycle {
+		result = append(result, compactMove{kind: compactRecycle, from: 1, to: 0, count: c.sizes[1]})
+	}
+	return result
+}
+
+// apply performs a move.
+func (c *compact) apply(m compactMove) {
+	from, to := &c.piles[m.from], &c.piles[m.to]
+	switch m.kind {
+	case compactFlip:
+		from[m.index] |= packedFaceUp
+	case compactShift:
+		for i := uint8(0); i < m.count; i++ {
+			to[c.sizes[m.to]+i] = from[m.index+i] | packedFaceUp
+		}
+		c.sizes[m.from] -= m.count
+		c.sizes[m.to] += m.count
+	case compactDraw, compactRecycle:
+		for i := uint8(0); i < m.count; i++ {
+			card := from[c.sizes[m.from]-1-i]
+			if m.kind == compactDraw {
+				card |= packedFaceUp
+			} else {
+				card &^= packedFaceUp
+			}
+			to[c.sizes[m.to]+i] = card
+		}
+		c.sizes[m.from] -= m.count
+		c.sizes[m.to] += m.count
+	}
+}
+
+// undo takes back a move made by apply. Cards that move between piles are
+// face up everywhere but the stock, so no other state needs to be kept.
+func (c *compact) undo(m compactMove) {
+	from, to := &c.piles[m.from], &c.piles[m.to]
+	switch m.kind {
+	case compactFlip:
+		from[m.index] &^= packedFaceUp
+	case compactShift:
+		c.sizes[m.to] -= m.count
+		for i := uint8(0); i < m.count; i++ {
+			from[m.index+i] = to[c.sizes[m.to]+i]
+		}
+		c.sizes[m.from] += m.count
+	case compactDraw, compactRecycle:
+		c.sizes[m.to] -= m.count
+		for i := uint8(0); i < m.count; i++ {
+			card := to[c.sizes[m.to]+m.count-1-i]
+			if m.kind == compactDraw {
+				card &^= packedFaceUp
+			} else {
+				card |= packedFaceUp
+			}
+			from[c.sizes[m.from]+i] = card
+		}
+		c.sizes[m.from] += m.count
+	}
+}
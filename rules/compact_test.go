@@ -0,0 +1,247 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/slcjordan/poc"
+)
+
+// board unpacks the board. Empty piles are nil.
+func (c *compact) board() poc.Board {
+	result := poc.Board{Piles: make([][]poc.PositionedCard, klondikePiles), Score: c.score}
+	for pileNum := range c.piles {
+		if c.sizes[pileNum] < 1 {
+			continue
+		}
+		pile := make([]poc.PositionedCard, c.sizes[pileNum])
+		for pileIndex := range pile {
+			pile[pileIndex] = c.piles[pileNum][pileIndex].unpack()
+		}
+		result.Piles[pileNum] = pile
+	}
+	return result
+}
+
+// expand lists a move as poc.Moves, the way klondikeMoves would. It has to be
+// called before the move is applied.
+func (c *compact) expand(m compactMove) []poc.Move {
+	result := make([]poc.Move, 0, m.count)
+	from := c.piles[m.from]
+	switch m.kind {
+	case compactFlip:
+		position := from[m.index].unpack().Position
+		result = append(result, poc.Move{
+			OldPileNum:      int(m.from),
+			OldPileIndex:    int(m.index),
+			OldPilePosition: position,
+			NewPileNum:      int(m.to),
+			NewPileIndex:    int(m.index),
+			NewPilePosition: position | poc.FaceUp,
+		})
+	case compactShift:
+		for i := 0; i < int(m.count); i++ {
+			position := from[int(m.index)+i].unpack().Position
+			newPosition := position | poc.FaceUp
+			if isFoundation(int(m.to)) {
+				newPosition = position
+			}
+			result = append(result, poc.Move{
+				OldPileNum:      int(m.from),
+				OldPileIndex:    int(m.index) + i,
+				OldPilePosition: position,
+				NewPileNum:      int(m.to),
+				NewPileIndex:    int(c.sizes[m.to]) + i,
+				NewPilePosition: newPosition,
+			})
+		}
+	case compactDraw, compactRecycle:
+		top := from[c.sizes[m.from]-1].unpack().Position
+		for i := 0; i < int(m.count); i++ {
+			oldPileIndex := int(c.sizes[m.from]) - 1 - i
+			position, newPosition := from[oldPileIndex].unpack().Position, top&^poc.FaceUp
+			if m.kind == compactDraw {
+				newPosition = position | poc.FaceUp
+			} else {
+				position = top
+			}
+			result = append(result, poc.Move{
+				OldPileNum:      int(m.from),
+				OldPileIndex:    oldPileIndex,
+				OldPilePosition: position,
+				NewPileNum:      int(m.to),
+				NewPileIndex:    int(c.sizes[m.to]) + i,
+				NewPilePosition: newPosition,
+			})
+		}
+	}
+	return result
+}
+
+// compactKey names a move group the same way whatever order its moves are in.
+func compactKey(moves []poc.Move) string {
+	keys := make([]string, len(moves))
+	for i, m := range moves {
+		keys[i] = fmt.Sprint(m)
+	}
+	sort.Strings(keys)
+	return fmt.Sprint(keys)
+}
+
+// playRandom deals a game and makes steps random moves, calling visit with
+// every position on the way.
+func playRandom(t testing.TB, variant poc.Variant, seed int64, steps int, visit func(poc.SavedGameDetail)) {
+	random := rand.New(rand.NewSource(seed))
+	start, err := Shuffle{random}.CallStartGame(context.Background(), poc.StartGame{Variant: variant})
+	if err != nil {
+		t.Fatal(err)
+	}
+	game := start.SavedGameDetail
+	for step := 0; step < steps; step++ {
+		visit(game)
+		possible := nextMoves(game)
+		if len(possible) < 1 {
+			return
+		}
+		next := possible[random.Intn(len(possible))]
+		piles, err := apply(game.Board.Piles, next)
+		if err != nil {
+			t.Fatal(err)
+		}
+		game.Board.Piles = piles
+		game.History = append(game.History, next)
+	}
+}
+
+// samePiles compares piles, treating empty piles and nil alike.
+func samePiles(a [][]poc.PositionedCard, b [][]poc.PositionedCard) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if (len(a[i]) > 0 || len(b[i]) > 0) && !reflect.DeepEqual(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestCompact(t *testing.T) {
+	variants := []poc.Variant{
+		{},
+		{DrawCount: 3, MaxTimesThroughDeck: 2},
+		{EmptyColumnFill: poc.AnyCardFill},
+		{EmptyColumnFill: poc.NoCardFill},
+		{Jokers: 2},
+	}
+	for _, variant := range variants {
+		for seed := int64(1); seed <= 5; seed++ {
+			playRandom(t, variant, seed, 80, func(game poc.SavedGameDetail) {
+				c, ok := toCompact(game.Board)
+				if !ok {
+					t.Fatalf("could not pack %v", game.Board)
+				}
+				if board := c.board(); !samePiles(board.Piles, game.Board.Piles) || board.Score != game.Board.Score {
+					t.Fatalf("%v came back as %v", game.Board, board)
+				}
+
+				standard := game // jokers are only moved on poc.Board
+				standard.Variant.Jokers = 0
+				want := make(map[string]bool)
+				for _, moves := range klondikeMoves(standard) {
+					want[compactKey(moves)] = true
+				}
+				got := c.moves(game.Variant, canRecycle(game), nil)
+				if len(got) != len(want) {
+					t.Fatalf("variant %+v seed %d: expected %d moves but got %d", variant, seed, len(want), len(got))
+				}
+				for _, m := range got {
+					moves := c.expand(m)
+					if !want[compactKey(moves)] {
+						t.Fatalf("variant %+v seed %d: unexpected move %v", variant, seed, moves)
+					}
+					piles, err := apply(game.Board.Piles, moves)
+					if err != nil {
+						t.Fatal(err)
+					}
+					before := c.board()
+					c.apply(m)
+					if after := c.board(); !samePiles(after.Piles, piles) {
+						t.Fatalf("%v gave %v but expected %v", moves, after.Piles, piles)
+					}
+					c.undo(m)
+					if after := c.board(); !samePiles(after.Piles, before.Piles) {
+						t.Fatalf("undoing %v gave %v but expected %v", moves, after.Piles, before.Piles)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestCompactDoesNotAllocate(t *testing.T) {
+	var game poc.SavedGameDetail
+	playRandom(t, poc.Variant{}, 1, 40, func(curr poc.SavedGameDetail) { game = curr })
+	c, _ := toCompact(game.Board)
+	buf := make([]compactMove, 0, 256)
+	allocs := testing.AllocsPerRun(100, func() {
+		for _, m := range c.moves(game.Variant, true, buf) {
+			c.apply(m)
+			c.undo(m)
+		}
+	})
+	if allocs > 0 {
+		t.Errorf("expected no allocations but got %v", allocs)
+	}
+}
+
+// BenchmarkNextMoves compares listing the moves of the same positions on
+// poc.Board and on the compact board.
+func BenchmarkNextMoves(b *testing.B) {
+	var games []poc.SavedGameDetail
+	playRandom(b, poc.Variant{}, 1, 100, func(game poc.SavedGameDetail) { games = append(games, game) })
+	compacts := make([]compact, len(games))
+	for i, game := range games {
+		compacts[i], _ = toCompact(game.Board)
+	}
+
+	b.Run("board", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			nextMoves(games[i%len(games)])
+		}
+	})
+	b.Run("compact", func(b *testing.B) {
+		b.ReportAllocs()
+		buf := make([]compactMove, 0, 256)
+		for i := 0; i < b.N; i++ {
+			c := &compacts[i%len(compacts)]
+			buf = c.moves(games[i%len(games)].Variant, true, buf)
+		}
+	})
+	b.Run("board apply", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			game := games[i%len(games)]
+			for _, moves := range nextMoves(game) {
+				apply(game.Board.Piles, moves)
+			}
+		}
+	})
+	b.Run("compact apply and undo", func(b *testing.B) {
+		b.ReportAllocs()
+		buf := make([]compactMove, 0, 256)
+		for i := 0; i < b.N; i++ {
+			c := &compacts[i%len(compacts)]
+			buf = c.moves(games[i%len(games)].Variant, true, buf)
+			for _, m := range buf {
+				c.apply(m)
+				c.undo(m)
+			}
+		}
+	})
+}
@@ -0,0 +1,81 @@
+package rules
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/slcjordan/poc"
+)
+
+// maxSeed keeps random seeds small enough to survive as javascript numbers.
+const maxSeed = 1<<53 - 1
+
+// newSeed reads a random seed in [1, maxSeed] from source.
+func newSeed(source io.Reader) (int64, error) {
+	var b [8]byte
+	_, err := io.ReadFull(source, b[:])
+	if err != nil {
+		return 0, fmt.Errorf("could not read seed: %w", err)
+	}
+	return int64(binary.BigEndian.Uint64(b[:])%maxSeed) + 1, nil
+}
+
+// dealSource is a splitmix64 generator. Unlike math/rand its output is fixed,
+// so a seed deals the same game in every release.
+type dealSource struct {
+	state uint64
+}
+
+func (d *dealSource) next() uint64 {
+	d.state += 0x9e3779b97f4a7c15
+	z := d.state
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// intn returns an unbiased number in [0, n).
+func (d *dealSource) intn(n int) int {
+	limit := math.MaxUint64 - math.MaxUint64%uint64(n)
+	for {
+		v := d.next()
+		if v < limit {
+			return int(v % uint64(n))
+		}
+	}
+}
+
+// deck lists the cards a variant is played with, in suit order within each
+// index and followed by any jokers.
+func deck(variant poc.Variant) []poc.PositionedCard {
+	cards := make([]poc.PositionedCard, 52, 52+variant.Jokers)
+	for i := range cards {
+		cards[i].Card.Suit = poc.Suit((i % 4) + 1)
+		cards[i].Card.Index = poc.Index((i / 4) + 1)
+	}
+	for _, joker := range jokerCards[:variant.Jokers] {
+		cards = append(cards, poc.PositionedCard{Card: joker})
+	}
+	return cards
+}
+
+// fisherYates shuffles a deck with a Fisher-Yates shuffle driven by seed.
+func fisherYates(cards []poc.PositionedCard, seed int64) []poc.PositionedCard {
+	source := dealSource{state: uint64(seed)}
+	for i := len(cards) - 1; i > 0; i-- {
+		j := source.intn(i + 1)
+		cards[i], cards[j] = cards[j], cards[i]
+	}
+	return cards
+}
+
+// deckOrder lists the cards of a shuffled deck.
+func deckOrder(cards []poc.PositionedCard) []poc.Card {
+	result := make([]poc.Card, len(cards))
+	for i, card := range cards {
+		result[i] = card.Card
+	}
+	return result
+}
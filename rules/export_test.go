@@ -0,0 +1,9 @@
+package rules
+
+import "github.com/slcjordan/poc"
+
+// ValidateBySearch validates a move group the way every ruleset used to: by
+// listing every possible move group and searching the sorted list for it.
+func ValidateBySearch(game poc.SavedGameDetail, moves []poc.Move) error {
+	return search(nextMoves(game), moves)
+}
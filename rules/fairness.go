@@ -0,0 +1,30 @@
+package rules
+
+import (
+	"context"
+	"errors"
+
+	"github.com/slcjordan/poc"
+)
+
+// ErrNoCommitment means a game was started before deals were committed to.
+var ErrNoCommitment = errors.New("game has no deal commitment")
+
+// Reveal shows the deck order behind a commitment once it can no longer help
+// the player.
+type Reveal struct{}
+
+// CallFairness reveals the deck order of won and resigned games. Stuck games
+// may still be undone, so their deck stays hidden. The salt is already in
+// game.SavedGameDetail, so Revealed says whether it may be shown.
+func (r Reveal) CallFairness(ctx context.Context, game poc.Fairness) (poc.Fairness, error) {
+	if game.SavedGameDetail.Commitment == "" {
+		return game, poc.Error{Actual: ErrNoCommitment, Category: poc.NotFoundError}
+	}
+	game.Revealed = game.SavedGameDetail.Revealed()
+	game.Deck = nil
+	if game.Revealed {
+		game.Deck = deckOrder(shuffle(game.SavedGameDetail.Variant, game.SavedGameDetail.Seed))
+	}
+	return game, nil
+}
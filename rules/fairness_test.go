@@ -0,0 +1,125 @@
+package rules_test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/slcjordan/poc"
+	"github.com/slcjordan/poc/fairness"
+	"github.com/slcjordan/poc/pipeline"
+	"github.com/slcjordan/poc/rules"
+	"github.com/slcjordan/poc/test/assert"
+	"github.com/slcjordan/poc/test/harness"
+	"github.com/slcjordan/poc/test/logger"
+)
+
+func TestReveal(t *testing.T) {
+	logger.RegisterVerbose(t)
+	inProgress := poc.SavedGameDetail{Seed: 12345, Salt: "73616c74", Commitment: "c0ffee"}
+	won := inProgress
+	won.Status = poc.Won
+	stuck := inProgress
+	stuck.Status = poc.Stuck
+
+	harness.Fairness{
+		{
+			Desc:    "Deck is hidden while the game is played",
+			Command: rules.Reveal{},
+			Input:   poc.Fairness{SavedGameDetail: inProgress},
+			Result: func() *assert.Assertion {
+				a := assert.New().NoError()
+				a.Fairness.Revealed(assert.EqualsBool(false))
+				return a.Fairness.Deck.Length(assert.Equals(0))
+			}(),
+		},
+		{
+			Desc:    "Deck is revealed once the game is over",
+			Command: rules.Reveal{},
+			Input:   poc.Fairness{SavedGameDetail: won},
+			Result: func() *assert.Assertion {
+				a := assert.New().NoError()
+				a.Fairness.Revealed(assert.EqualsBool(true))
+				a.Fairness.Deck.Length(assert.Equals(52))
+				a.Fairness.Deck.Nth(0).Suit.Uint8(assert.Equals(poc.Spades))
+				return a.Fairness.Deck.Nth(0).Index.Uint8(assert.Equals(poc.Eight))
+			}(),
+		},
+		{
+			Desc:    "Deck is hidden while a stuck game may be undone",
+			Command: rules.Reveal{},
+			Input:   poc.Fairness{SavedGameDetail: stuck},
+			Result: func() *assert.Assertion {
+				a := assert.New().NoError()
+				a.Fairness.Revealed(assert.EqualsBool(false))
+				return a.Fairness.Deck.Length(assert.Equals(0))
+			}(),
+		},
+		{
+			Desc:    "Game without a commitment",
+			Command: rules.Reveal{},
+			Input:   poc.Fairness{SavedGameDetail: poc.SavedGameDetail{Status: poc.Won}},
+			Result:  assert.New().Error.Category.Uint8(assert.Equals(poc.NotFoundError)),
+		},
+	}.Run(t)
+}
+
+func TestShuffleCommitment(t *testing.T) {
+	logger.RegisterVerbose(t)
+	ctx := context.Background()
+	started, err := rules.Shuffle{rand.New(rand.NewSource(0))}.CallStartGame(ctx, poc.StartGame{})
+	if err != nil {
+		t.Fatalf("could not start game: %s", err)
+	}
+	game := poc.Fairness{SavedGameDetail: started.SavedGameDetail}
+	game.SavedGameDetail.Status = poc.Resigned
+	game, err = rules.Reveal{}.CallFairness(ctx, game)
+	if err != nil {
+		t.Fatalf("could not reveal deck: %s", err)
+	}
+	if !fairness.Verify(game.SavedGameDetail.Commitment, game.SavedGameDetail.Salt, game.Deck) {
+		t.Errorf("revealed deck does not match commitment %s", game.SavedGameDetail.Commitment)
+	}
+}
+
+// TestStuckRevealUndo checks that a stuck game can't have its deck revealed
+// and then be undone to carry on with the deal known.
+func TestStuckRevealUndo(t *testing.T) {
+	ctx := context.Background()
+	started, err := pipeline.StartGame{rules.Shuffle{rand.New(rand.NewSource(0))}, rules.NextMove{}}.CallStartGame(ctx, poc.StartGame{Variant: poc.Variant{AllowUndo: true}})
+	if err != nil {
+		t.Fatalf("could not start game: %s", err)
+	}
+	move, err := rules.Apply{}.CallPerformMove(ctx, poc.PerformMove{
+		SavedGameDetail: started.SavedGameDetail,
+		Next:            started.SavedGameDetail.PossibleNextMoves[0],
+	})
+	if err != nil {
+		t.Fatalf("could not move: %s", err)
+	}
+	game := move.SavedGameDetail
+	game.Status = poc.Stuck
+
+	revealed, err := rules.Reveal{}.CallFairness(ctx, poc.Fairness{SavedGameDetail: game})
+	if err != nil {
+		t.Fatalf("could not check fairness: %s", err)
+	}
+	if revealed.Revealed || len(revealed.Deck) > 0 {
+		t.Errorf("the deck of a stuck game was revealed")
+	}
+	undone, err := rules.Undo{}.CallUndoMove(ctx, poc.UndoMove{SavedGameDetail: game})
+	if err != nil {
+		t.Fatalf("could not undo: %s", err)
+	}
+	undone, err = rules.Status{}.CallUndoMove(ctx, undone)
+	if err != nil {
+		t.Fatalf("could not check status: %s", err)
+	}
+	revealed, err = rules.Reveal{}.CallFairness(ctx, poc.Fairness{SavedGameDetail: undone.SavedGameDetail})
+	if err != nil {
+		t.Fatalf("could not check fairness: %s", err)
+	}
+	if revealed.Revealed || len(revealed.Deck) > 0 {
+		t.Errorf("the deck was revealed after undoing a stuck game")
+	}
+}
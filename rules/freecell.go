@@ -0,0 +1,236 @@
+package rules
+
+import (
+	"errors"
+
+	"github.com/slcjordan/poc"
+)
+
+// ErrFreecellDeal means a FreeCell game was asked for with a deal number the
+// Microsoft deal generator does not accept.
+var ErrFreecellDeal = errors.New("freecell deals are numbered from 1 to 2147483647")
+
+// FreeCell piles: four free cells, eight tableau piles and a foundation for
+// each suit, in suit order.
+const (
+	freecellTableau    = 4
+	freecellFoundation = 12
+	freecellPiles      = 16
+)
+
+// FreeCell deal numbers. Random deals are picked from the 32000 deals of the
+// original Microsoft FreeCell.
+const (
+	freecellDeals   = 32000
+	maxFreecellDeal = 1<<31 - 1
+)
+
+// freecellShuffle deals the cards in the order Microsoft FreeCell deals them
+// for deal number seed. The deck starts as Aces to Kings of Clubs, Diamonds,
+// Hearts and Spades in turn and each card is drawn with the Microsoft C
+// runtime's rand.
+func freecellShuffle(seed int64) []poc.PositionedCard {
+	suits := []poc.Suit{poc.Clubs, poc.Diamonds, poc.Hearts, poc.Spades}
+	deck := make([]poc.PositionedCard, 52)
+	for i := range deck {
+		deck[i] = poc.PositionedCard{
+			Position: poc.FaceUp,
+			Card:     poc.Card{Suit: suits[i%4], Index: poc.Index(i/4) + poc.Ace},
+		}
+	}
+	state := uint32(seed)
+	cards := make([]poc.PositionedCard, 0, len(deck))
+	for left := len(deck); left > 0; left-- {
+		state = state*214013 + 2531011
+		j := int(state>>16&0x7fff) % left
+		cards = append(cards, deck[j])
+		deck[j] = deck[left-1]
+	}
+	return cards
+}
+
+// freecellDeal deals the cards face up across the tableau a row at a time.
+func freecellDeal(cards []poc.PositionedCard) [][]poc.PositionedCard {
+	piles := make([][]poc.PositionedCard, freecellPiles)
+	for i, card := range cards {
+		pileNum := freecellTableau + i%8
+		piles[pileNum] = append(piles[pileNum], card)
+	}
+	return piles
+}
+
+// isRed reports whether a card is a Heart or a Diamond.
+func isRed(card poc.Card) bool {
+	return card.Suit == poc.Hearts || card.Suit == poc.Diamonds
+}
+
+// buildsOn reports whether card may be placed on top of dest in the tableau.
+func buildsOn(card poc.Card, dest poc.Card) bool {
+	return dest.Index == card.Index+1 && isRed(dest) != isRed(card)
+}
+
+// freecellRunStart finds where the run of cards in alternating colours, each
+// one lower than the last, starts at the top of a pile.
+func freecellRunStart(pile []poc.PositionedCard) int {
+	start := len(pile) - 1
+	for start > 0 && buildsOn(pile[start].Card, pile[start-1].Card) {
+		start--
+	}
+	return start
+}
+
+// supermove is the most cards that can be moved at once with the free cells
+// and empty tableau piles left over. Every empty pile doubles it, but a pile
+// being moved into can't be used along the way.
+func supermove(freeCells int, emptyPiles int) int {
+	return (freeCells + 1) << emptyPiles
+}
+
+// freecellMoves lists the moves of a FreeCell game. Top cards go to their
+// foundation or any free cell, cards leave free cells for the tableau or a
+// foundation and runs move together when there is room for a supermove.
+// Moves into an empty cell or pile are listed for each of them, so that any
+// of them may be picked.
+func freecellMoves(game poc.SavedGameDetail) [][]poc.Move {
+	piles := game.Board.Piles
+	cells := piles[:freecellTableau]
+	tableau := piles[freecellTableau:freecellFoundation]
+
+	var result [][]poc.Move
+	var freeCells []int
+	for i, cell := range cells {
+		if len(cell) < 1 {
+			freeCells = append(freeCells, i)
+		}
+	}
+	var emptyPiles []int
+	for i, pile := range tableau {
+		if len(pile) < 1 {
+			emptyPiles = append(emptyPiles, freecellTableau+i)
+		}
+	}
+
+	// toFoundation moves the top card of a pile onto its foundation pile.
+	toFoundation := func(pileNum int) {
+		pile := piles[pileNum]
+		card := pile[len(pile)-1].Card
+		foundation := freecellFoundation + int(card.Suit) - 1
+		if len(piles[foundation]) == int(card.Index-poc.Ace) {
+			result = append(result, moveCards(piles, pileNum, len(pile)-1, foundation))
+		}
+	}
+
+	for i, cell := range cells {
+		if len(cell) < 1 {
+			continue
+		}
+		toFoundation(i)
+		for j, dest := range tableau {
+			if len(dest) > 0 && buildsOn(cell[0].Card, dest[len(dest)-1].Card) {
+				result = append(result, moveCards(piles, i, 0, freecellTableau+j))
+			}
+		}
+		for _, emptyPile := range emptyPiles {
+			result = append(result, moveCards(piles, i, 0, emptyPile))
+		}
+	}
+
+	for i, pile := range tableau {
+		pileNum := freecellTableau + i
+		if len(pile) < 1 {
+			continue
+		}
+		toFoundation(pileNum)
+		for _, freeCell := range freeCells {
+			result = append(result, moveCards(piles, pileNum, len(pile)-1, freeCell))
+		}
+		for idx := freecellRunStart(pile); idx < len(pile); idx++ {
+			size := len(pile) - idx
+			for j, dest := range tableau {
+				if j == i || len(dest) < 1 || size > supermove(len(freeCells), len(emptyPiles)) {
+					continue
+				}
+				if buildsOn(pile[idx].Card, dest[len(dest)-1].Card) {
+					result = append(result, moveCards(piles, pileNum, idx, freecellTableau+j))
+				}
+			}
+			// moving a whole pile into an empty one changes nothing
+			if idx == 0 || len(emptyPiles) < 1 || size > supermove(len(freeCells), len(emptyPiles)-1) {
+				continue
+			}
+			for _, emptyPile := range emptyPiles {
+				result = append(result, moveCards(piles, pileNum, idx, emptyPile))
+			}
+		}
+	}
+	return result
+}
+
+// freecellWon reports whether every foundation has been built up to its King.
+func freecellWon(game poc.SavedGameDetail) bool {
+	for _, pile := range game.Board.Piles[freecellFoundation:] {
+		if len(pile) < 13 {
+			return false
+		}
+	}
+	return true
+}
+
+func init() {
+	Register("freecell", freecell{})
+}
+
+// freecell is the FreeCell ruleset.
+type freecell struct{}
+
+func (f freecell) Piles(variant poc.Variant) int {
+	return freecellPiles
+}
+
+// Seed picks one of the deals players know.
+func (f freecell) Seed(random int64) int64 {
+	return (random-1)%freecellDeals + 1
+}
+
+// Setup checks the deal number.
+func (f freecell) Setup(variant poc.Variant, seed int64) (poc.Variant, error) {
+	if seed < 1 || seed > maxFreecellDeal {
+		return variant, ErrFreecellDeal
+	}
+	return variant, nil
+}
+
+func (f freecell) Shuffle(variant poc.Variant, seed int64) []poc.PositionedCard {
+	return freecellShuffle(seed)
+}
+
+func (f freecell) Deal(variant poc.Variant, cards []poc.PositionedCard) poc.Board {
+	return poc.Board{Piles: freecellDeal(cards)}
+}
+
+func (f freecell) Moves(game poc.SavedGameDetail) [][]poc.Move {
+	return freecellMoves(game)
+}
+
+func (f freecell) Validate(game poc.SavedGameDetail, moves []poc.Move) error {
+	return search(f.Moves(game), moves)
+}
+
+// Start is 0 as FreeCell is not scored.
+func (f freecell) Start(variant poc.Variant) int32 {
+	return 0
+}
+
+func (f freecell) Points(variant poc.Variant, moves []poc.Move) int32 {
+	return 0
+}
+
+func (f freecell) Won(game poc.SavedGameDetail) bool {
+	return freecellWon(game)
+}
+
+// Stuck reports whether there are no moves left. There is no stock to cycle
+// through.
+func (f freecell) Stuck(game poc.SavedGameDetail, limit int) bool {
+	return len(f.Moves(game)) < 1
+}
@@ -0,0 +1,158 @@
+package rules_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/slcjordan/poc"
+	"github.com/slcjordan/poc/rules"
+	"github.com/slcjordan/poc/test/assert"
+	"github.com/slcjordan/poc/test/harness"
+	"github.com/slcjordan/poc/test/logger"
+)
+
+func TestFreeCell(t *testing.T) {
+	logger.RegisterVerbose(t)
+	freecell := poc.Variant{Family: poc.FreeCell}
+	faceUp := func(suit poc.Suit, index poc.Index) poc.PositionedCard {
+		return poc.PositionedCard{Position: poc.FaceUp, Card: poc.Card{Suit: suit, Index: index}}
+	}
+
+	roomy := poc.Board{Piles: make([][]poc.PositionedCard, 16)} // two free cells and an empty pile
+	roomy.Piles[0] = []poc.PositionedCard{faceUp(poc.Clubs, poc.King)}
+	roomy.Piles[1] = []poc.PositionedCard{faceUp(poc.Diamonds, poc.King)}
+	roomy.Piles[4] = []poc.PositionedCard{
+		faceUp(poc.Hearts, poc.Nine),
+		faceUp(poc.Spades, poc.Eight),
+		faceUp(poc.Hearts, poc.Seven),
+		faceUp(poc.Spades, poc.Six),
+	}
+	roomy.Piles[5] = []poc.PositionedCard{faceUp(poc.Spades, poc.Ten)}
+	for pileNum := 6; pileNum < 11; pileNum++ {
+		roomy.Piles[pileNum] = []poc.PositionedCard{faceUp(poc.Clubs, poc.Index(pileNum))}
+	}
+	spacious := clone(roomy) // a second empty pile
+	spacious.Piles[10] = nil
+	cramped := clone(roomy) // no free cells and no empty piles
+	cramped.Piles[2] = []poc.PositionedCard{faceUp(poc.Hearts, poc.King)}
+	cramped.Piles[3] = []poc.PositionedCard{faceUp(poc.Spades, poc.King)}
+	cramped.Piles[11] = []poc.PositionedCard{faceUp(poc.Diamonds, poc.Queen)}
+
+	// run moves the top count cards of pile 4 onto newPileNum.
+	run := func(count int, newPileNum int, newPileIndex int) []poc.Move {
+		var moves []poc.Move
+		for i := 4 - count; i < 4; i++ {
+			moves = append(moves, poc.Move{
+				OldPileNum:      4,
+				OldPileIndex:    i,
+				OldPilePosition: poc.FaceUp,
+				NewPileNum:      newPileNum,
+				NewPileIndex:    newPileIndex + i - (4 - count),
+				NewPilePosition: poc.FaceUp,
+			})
+		}
+		return moves
+	}
+
+	harness.StartGame{
+		{
+			Desc:    "Deal #1 matches Microsoft FreeCell",
+			Command: rules.Shuffle{rand.New(rand.NewSource(0))},
+			Input:   poc.StartGame{Seed: 1, Variant: freecell},
+			Result: func() *assert.Assertion {
+				a := assert.New().NoError()
+				a.StartGame.SavedGameDetail.Board.Piles.Length(assert.Equals(16))
+				a.StartGame.SavedGameDetail.Board.Piles.Nth(4).Length(assert.Equals(7))
+				a.StartGame.SavedGameDetail.Board.Piles.Nth(11).Length(assert.Equals(6))
+				a.StartGame.SavedGameDetail.Board.Piles.Nth(4).Nth(0).Card.Suit.Uint8(assert.Equals(poc.Diamonds))
+				a.StartGame.SavedGameDetail.Board.Piles.Nth(4).Nth(0).Card.Index.Uint8(assert.Equals(poc.Jack))
+				a.StartGame.SavedGameDetail.Board.Piles.Nth(5).Nth(0).Card.Suit.Uint8(assert.Equals(poc.Diamonds))
+				a.StartGame.SavedGameDetail.Board.Piles.Nth(5).Nth(0).Card.Index.Uint8(assert.Equals(poc.Two))
+				a.StartGame.SavedGameDetail.Board.Piles.Nth(4).Nth(1).Card.Suit.Uint8(assert.Equals(poc.Diamonds))
+				a.StartGame.SavedGameDetail.Board.Piles.Nth(4).Nth(1).Card.Index.Uint8(assert.Equals(poc.King))
+				return a.StartGame.SavedGameDetail.Board.Piles.Nth(4).Nth(0).Position.Uint64(assert.Equals(poc.FaceUp))
+			}(),
+		},
+		{
+			Desc:    "Random deals are numbered like the original game",
+			Command: rules.Shuffle{rand.New(rand.NewSource(0))},
+			Input:   poc.StartGame{Variant: freecell},
+			Result: assert.New().NoError().
+				StartGame.SavedGameDetail.Seed(assert.Equals(24781)),
+		},
+		{
+			Desc:    "Deal numbers stop at 2147483647",
+			Command: rules.Shuffle{rand.New(rand.NewSource(0))},
+			Input:   poc.StartGame{Seed: 1 << 31, Variant: freecell},
+			Result:  assert.New().Error.Category.Uint8(assert.Equals(poc.MalformedError)),
+		},
+	}.Run(t)
+
+	harness.PerformMove{
+		{
+			Desc:    "Supermove onto a card",
+			Command: rules.Validate{},
+			Input: poc.PerformMove{
+				Next:            run(4, 5, 1),
+				SavedGameDetail: poc.SavedGameDetail{Board: roomy, Variant: freecell},
+			},
+			Result: assert.New().NoError(),
+		},
+		{
+			Desc:    "Supermove into an empty pile",
+			Command: rules.Validate{},
+			Input: poc.PerformMove{
+				Next:            run(3, 11, 0),
+				SavedGameDetail: poc.SavedGameDetail{Board: roomy, Variant: freecell},
+			},
+			Result: assert.New().NoError(),
+		},
+		{
+			Desc:    "Supermove into the second empty pile",
+			Command: rules.Validate{},
+			Input: poc.PerformMove{
+				Next:            run(3, 11, 0),
+				SavedGameDetail: poc.SavedGameDetail{Board: spacious, Variant: freecell},
+			},
+			Result: assert.New().NoError(),
+		},
+		{
+			Desc:    "Free cell to the second empty pile",
+			Command: rules.Validate{},
+			Input: poc.PerformMove{
+				Next: []poc.Move{
+					{OldPileNum: 0, OldPilePosition: poc.FaceUp, NewPileNum: 11, NewPilePosition: poc.FaceUp},
+				},
+				SavedGameDetail: poc.SavedGameDetail{Board: spacious, Variant: freecell},
+			},
+			Result: assert.New().NoError(),
+		},
+		{
+			Desc:    "Too many cards for the free cells",
+			Command: rules.Validate{},
+			Input: poc.PerformMove{
+				Next:            run(2, 5, 1),
+				SavedGameDetail: poc.SavedGameDetail{Board: cramped, Variant: freecell},
+			},
+			Result: assert.New().Error.Category.Uint8(assert.Equals(poc.SemanticError)),
+		},
+		{
+			Desc:    "Top card to a free cell",
+			Command: rules.Validate{},
+			Input: poc.PerformMove{
+				Next:            run(1, 2, 0),
+				SavedGameDetail: poc.SavedGameDetail{Board: roomy, Variant: freecell},
+			},
+			Result: assert.New().NoError(),
+		},
+		{
+			Desc:    "Top card to the second free cell",
+			Command: rules.Validate{},
+			Input: poc.PerformMove{
+				Next:            run(1, 3, 0),
+				SavedGameDetail: poc.SavedGameDetail{Board: roomy, Variant: freecell},
+			},
+			Result: assert.New().NoError(),
+		},
+	}.Run(t)
+}
@@ -0,0 +1,103 @@
+package rules
+
+import (
+	"context"
+	"sort"
+
+	"github.com/slcjordan/poc"
+)
+
+// faceDownBelow counts the face down cards under the given tableau index.
+func faceDownBelow(pile []poc.PositionedCard, pileIndex int) int32 {
+	var result int32
+	for _, card := range pile[:pileIndex] {
+		if card.Position&poc.FaceUp == 0 {
+			result++
+		}
+	}
+	return result
+}
+
+// splitsRun reports whether a tableau to tableau move splits a run when the
+// card left behind can't go to its foundation either. That rarely helps.
+func splitsRun(game poc.SavedGameDetail, moves []poc.Move) bool {
+	first := moves[0]
+	if first.OldPileIndex == 0 {
+		return false
+	}
+	below := game.Board.Piles[first.OldPileNum][first.OldPileIndex-1]
+	if below.Position&poc.FaceUp == 0 || isJoker(below.Card) {
+		return false
+	}
+	foundation := game.Board.Piles[below.Card.Suit+8]
+	return len(foundation) != int(below.Card.Index-poc.Ace)
+}
+
+// rankMove scores a move group. Foundation plays come first, then moves that
+// reveal face down cards (preferring piles with the most cards left to
+// reveal), then moves that empty a column.
+func rankMove(game poc.SavedGameDetail, moves []poc.Move) poc.RankedMove {
+	first := moves[0]
+	result := poc.RankedMove{Moves: moves}
+	switch {
+	case isFoundation(first.NewPileNum) && !isFoundation(first.OldPileNum):
+		result.Reason = poc.ToFoundation
+		result.Score = 100
+	case first.OldPileNum == first.NewPileNum:
+		result.Reason = poc.RevealCard
+		result.Score = 90
+	case isTableau(first.OldPileNum) && isTableau(first.NewPileNum):
+		pile := game.Board.Piles[first.OldPileNum]
+		below := faceDownBelow(pile, first.OldPileIndex)
+		switch {
+		case below > 0 && pile[first.OldPileIndex-1].Position&poc.FaceUp == 0:
+			result.Reason = poc.RevealCard
+			result.Score = 80 + below
+		case first.OldPileIndex == 0 && first.NewPileIndex > 0:
+			result.Reason = poc.EmptyColumn
+			result.Score = 70
+		case splitsRun(game, moves):
+			result.Reason = poc.BuildTableau
+			result.Score = 10
+		default:
+			result.Reason = poc.BuildTableau
+			result.Score = 30
+		}
+	case first.OldPileNum == 1 && isTableau(first.NewPileNum):
+		result.Reason = poc.PlayTalon
+		result.Score = 50
+	case isStockMove(moves) && first.NewPileNum == 1:
+		result.Reason = poc.DrawStock
+		result.Score = 20
+	case isStockMove(moves):
+		result.Reason = poc.RecycleTalon
+		result.Score = 15
+	default:
+		result.Reason = poc.FromFoundation
+		result.Score = 5
+	}
+	return result
+}
+
+// Hint suggests moves.
+type Hint struct{}
+
+// CallHint ranks every possible next move, best first, and counts the hint
+// against the game.
+func (h Hint) CallHint(ctx context.Context, game poc.Hint) (poc.Hint, error) {
+	if game.SavedGameDetail.Variant.Family != poc.Klondike {
+		return game, poc.Error{Actual: ErrKlondikeOnly, Category: poc.UnimplementedError}
+	}
+	if game.SavedGameDetail.Status != poc.InProgress {
+		return game, poc.Error{Actual: ErrGameOver, Category: poc.SemanticError}
+	}
+	game.Moves = nil
+	for _, moves := range nextMoves(game.SavedGameDetail) {
+		game.Moves = append(game.Moves, rankMove(game.SavedGameDetail, moves))
+	}
+	sort.SliceStable(game.Moves, func(i int, j int) bool {
+		return game.Moves[i].Score > game.Moves[j].Score
+	})
+	game.SavedGameDetail.HintsUsed++
+	return game, nil
+}
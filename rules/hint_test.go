@@ -0,0 +1,80 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/slcjordan/poc"
+	"github.com/slcjordan/poc/pipeline"
+	"github.com/slcjordan/poc/rules"
+	"github.com/slcjordan/poc/test/assert"
+	"github.com/slcjordan/poc/test/harness"
+	"github.com/slcjordan/poc/test/logger"
+)
+
+func TestHint(t *testing.T) {
+	logger.RegisterVerbose(t)
+	board := klondike()
+	board.Piles[1] = []poc.PositionedCard{
+		{Position: poc.FaceUp, Card: poc.Card{Suit: poc.Hearts, Index: poc.Ace}},
+	}
+	board.Piles[2] = []poc.PositionedCard{
+		{Card: poc.Card{Suit: poc.Clubs, Index: poc.King}},
+		{Position: poc.FaceUp, Card: poc.Card{Suit: poc.Hearts, Index: poc.Seven}},
+	}
+	board.Piles[3] = []poc.PositionedCard{
+		{Position: poc.FaceUp, Card: poc.Card{Suit: poc.Spades, Index: poc.Eight}},
+	}
+	harness.Hint{
+		{
+			Desc:    "Ranks foundation plays, then reveals, then the stock",
+			Command: pipeline.Hint{rules.Hint{}, rules.Score{}},
+			Input: poc.Hint{
+				SavedGameDetail: poc.SavedGameDetail{
+					Board:     board,
+					HintsUsed: 1,
+				},
+			},
+			Result: func() *assert.Assertion {
+				a := assert.New().NoError()
+				a.Hint.Moves.Length(assert.Equals(3))
+				a.Hint.Moves.Nth(0).Reason.Uint8(assert.Equals(poc.ToFoundation))
+				a.Hint.Moves.Nth(1).Reason.Uint8(assert.Equals(poc.RevealCard))
+				a.Hint.Moves.Nth(2).Reason.Uint8(assert.Equals(poc.RecycleTalon))
+				a.Hint.SavedGameDetail.HintsUsed(assert.Equals(2))
+				return a
+			}(),
+		},
+		{
+			Desc:    "Standard hint penalty never drops the score below zero",
+			Command: pipeline.Hint{rules.Hint{}, rules.Score{}},
+			Input: poc.Hint{
+				SavedGameDetail: poc.SavedGameDetail{
+					Board:   poc.Board{Score: 10},
+					Variant: poc.Variant{HintPenalty: 20},
+				},
+			},
+			Result: assert.New().NoError().
+				Hint.SavedGameDetail.Board.Score(assert.Equals(0)),
+		},
+		{
+			Desc:    "Vegas hint penalty",
+			Command: pipeline.Hint{rules.Hint{}, rules.Score{}},
+			Input: poc.Hint{
+				SavedGameDetail: poc.SavedGameDetail{
+					Board:   poc.Board{Score: 10},
+					Variant: poc.Variant{HintPenalty: 20, Scoring: poc.VegasScoring},
+				},
+			},
+			Result: assert.New().NoError().
+				Hint.SavedGameDetail.Board.Score(assert.Equals(-10)),
+		},
+		{
+			Desc:    "Finished games get no hints",
+			Command: rules.Hint{},
+			Input: poc.Hint{
+				SavedGameDetail: poc.SavedGameDetail{Status: poc.Won},
+			},
+			Result: assert.New().Error.Category.Uint8(assert.Equals(poc.SemanticError)),
+		},
+	}.Run(t)
+}
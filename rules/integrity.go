@@ -0,0 +1,98 @@
+package rules
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/slcjordan/poc"
+)
+
+// ErrCorruptBoard means a board could not have come from playing its game.
+var ErrCorruptBoard = errors.New("corrupt board")
+
+// integrityChecker is implemented by rulesets with pile invariants of their
+// own, checked after the deck itself is known to be whole.
+type integrityChecker interface {
+	CheckIntegrity(game poc.SavedGameDetail) error
+}
+
+// corrupt describes what is wrong with a board.
+func corrupt(format string, a ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrCorruptBoard, fmt.Sprintf(format, a...))
+}
+
+// validCard reports whether a card is one of the 52 standard cards or a joker.
+func validCard(card poc.Card) bool {
+	if card.Suit == poc.Joker {
+		return card.Index == poc.Juggler || card.Index == poc.Fool
+	}
+	return card.Suit >= poc.Hearts && card.Suit <= poc.Spades && card.Index >= poc.Ace && card.Index <= poc.King
+}
+
+// checkIntegrity checks that the board holds exactly the deck its variant is
+// dealt, that every card is positioned sensibly, and that no pile has a face
+// down card on top of a face up one.
+func checkIntegrity(game poc.SavedGameDetail) error {
+	r, ok := ruleset(game.Variant)
+	if !ok {
+		return ErrUnknownRuleset
+	}
+	piles := game.Board.Piles
+	if len(piles) != r.Piles(game.Variant) {
+		return corrupt("%d piles but %s has %d", len(piles), rulesetName(game.Variant), r.Piles(game.Variant))
+	}
+
+	cards := r.Shuffle(game.Variant, game.Seed)
+	want := make(map[poc.Card]int)
+	for _, card := range cards {
+		want[card.Card]++
+	}
+	for pileNum, pile := range piles {
+		for pileIndex, card := range pile {
+			switch {
+			case !validCard(card.Card):
+				return corrupt("pile %d index %d holds suit %d index %d", pileNum, pileIndex, card.Card.Suit, card.Card.Index)
+			case card.Position&^poc.FaceUp != 0:
+				return corrupt("pile %d index %d has position %d", pileNum, pileIndex, card.Position)
+			case pileIndex > 0 && card.Position&poc.FaceUp == 0 && pile[pileIndex-1].Position&poc.FaceUp != 0:
+				return corrupt("pile %d index %d is face down on a face up card", pileNum, pileIndex)
+			case want[card.Card] < 1:
+				return corrupt("pile %d index %d holds an extra %s of %s", pileNum, pileIndex, card.Card.Index, card.Card.Suit)
+			}
+			want[card.Card]--
+		}
+	}
+	for _, card := range cards {
+		if want[card.Card] > 0 {
+			return corrupt("missing the %s of %s", card.Card.Index, card.Card.Suit)
+		}
+	}
+
+	if checker, ok := r.(integrityChecker); ok {
+		return checker.CheckIntegrity(game)
+	}
+	return nil
+}
+
+// CheckIntegrity turns away boards that could not have come from playing
+// their game, such as a corrupt row in the database or a bad import.
+type CheckIntegrity struct{}
+
+// CallPerformMove checks the board before the move is made.
+func (c CheckIntegrity) CallPerformMove(ctx context.Context, move poc.PerformMove) (poc.PerformMove, error) {
+	err := checkIntegrity(move.SavedGameDetail)
+	if err != nil {
+		return move, poc.Error{Actual: err, Category: poc.IntegrityError}
+	}
+	return move, nil
+}
+
+// CallLookupGame checks the board of the game.
+func (c CheckIntegrity) CallLookupGame(ctx context.Context, game poc.LookupGame) (poc.LookupGame, error) {
+	err := checkIntegrity(game.SavedGameDetail)
+	if err != nil {
+		return game, poc.Error{Actual: err, Category: poc.IntegrityError}
+	}
+	return game, nil
+}
@@ -0,0 +1,168 @@
+package rules_test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/slcjordan/poc"
+	"github.com/slcjordan/poc/pipeline"
+	"github.com/slcjordan/poc/rules"
+	"github.com/slcjordan/poc/test/assert"
+	"github.com/slcjordan/poc/test/harness"
+	"github.com/slcjordan/poc/test/logger"
+)
+
+// dealt deals a Klondike game by seed.
+func dealt(t *testing.T, variant poc.Variant) poc.SavedGameDetail {
+	start, err := rules.Shuffle{rand.New(rand.NewSource(1))}.CallStartGame(context.Background(), poc.StartGame{Variant: variant, Seed: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return start.SavedGameDetail
+}
+
+func TestCheckIntegrity(t *testing.T) {
+	logger.RegisterVerbose(t)
+	game := dealt(t, poc.Variant{})
+	corrupt := func(change func(board *poc.Board)) poc.PerformMove {
+		result := game
+		result.Board = clone(game.Board)
+		for pileNum, pile := range result.Board.Piles {
+			result.Board.Piles[pileNum] = append([]poc.PositionedCard(nil), pile...)
+		}
+		change(&result.Board)
+		return poc.PerformMove{SavedGameDetail: result}
+	}
+	isCorrupt := assert.New().Error.Category.Uint8(assert.Equals(poc.IntegrityError))
+
+	harness.PerformMove{
+		{
+			Desc:    "A fresh deal",
+			Command: rules.CheckIntegrity{},
+			Input:   poc.PerformMove{SavedGameDetail: game},
+			Result:  assert.New().NoError(),
+		},
+		{
+			Desc:    "Jokers are part of the deck",
+			Command: rules.CheckIntegrity{},
+			Input:   poc.PerformMove{SavedGameDetail: dealt(t, poc.Variant{Jokers: 2})},
+			Result:  assert.New().NoError(),
+		},
+		{
+			Desc:    "Jokers that were never dealt",
+			Command: rules.CheckIntegrity{},
+			Input: poc.PerformMove{SavedGameDetail: func() poc.SavedGameDetail {
+				withJokers := dealt(t, poc.Variant{Jokers: 2})
+				withJokers.Variant.Jokers = 0
+				return withJokers
+			}()},
+			Result: isCorrupt,
+		},
+		{
+			Desc:    "Duplicate card",
+			Command: rules.CheckIntegrity{},
+			Input: corrupt(func(board *poc.Board) {
+				board.Piles[0][0] = board.Piles[0][1]
+			}),
+			Result: isCorrupt,
+		},
+		{
+			Desc:    "Missing card",
+			Command: rules.CheckIntegrity{},
+			Input: corrupt(func(board *poc.Board) {
+				board.Piles[0] = board.Piles[0][1:]
+			}),
+			Result: isCorrupt,
+		},
+		{
+			Desc:    "Suit out of range",
+			Command: rules.CheckIntegrity{},
+			Input: corrupt(func(board *poc.Board) {
+				board.Piles[0][0].Card.Suit = poc.Joker + 1
+			}),
+			Result: isCorrupt,
+		},
+		{
+			Desc:    "Index out of range",
+			Command: rules.CheckIntegrity{},
+			Input: corrupt(func(board *poc.Board) {
+				board.Piles[0][0].Card.Index = 0
+			}),
+			Result: isCorrupt,
+		},
+		{
+			Desc:    "Face down card on a face up card",
+			Command: rules.CheckIntegrity{},
+			Input: corrupt(func(board *poc.Board) {
+				board.Piles[8][5].Position = poc.FaceUp
+				board.Piles[8][6].Position = 0
+			}),
+			Result: isCorrupt,
+		},
+		{
+			Desc:    "Face up card in the stock",
+			Command: rules.CheckIntegrity{},
+			Input: corrupt(func(board *poc.Board) {
+				board.Piles[0][len(board.Piles[0])-1].Position = poc.FaceUp
+			}),
+			Result: isCorrupt,
+		},
+		{
+			Desc:    "Foundation of the wrong suit",
+			Command: rules.CheckIntegrity{},
+			Input: corrupt(func(board *poc.Board) {
+				board.Piles[9] = []poc.PositionedCard{{Position: poc.FaceUp, Card: poc.Card{Suit: poc.Spades, Index: poc.Ace}}}
+				for pileNum, pile := range board.Piles {
+					for i, card := range pile {
+						if pileNum != 9 && card.Card == board.Piles[9][0].Card {
+							board.Piles[pileNum] = append(pile[:i:i], pile[i+1:]...)
+						}
+					}
+				}
+			}),
+			Result: isCorrupt,
+		},
+		{
+			Desc:    "Too few piles",
+			Command: rules.CheckIntegrity{},
+			Input: corrupt(func(board *poc.Board) {
+				board.Piles = board.Piles[:12]
+			}),
+			Result: isCorrupt,
+		},
+	}.Run(t)
+}
+
+// TestIntegrityHoldsInPlay plays random games of every ruleset and checks
+// that no move leaves a board CheckIntegrity turns away.
+func TestIntegrityHoldsInPlay(t *testing.T) {
+	ctx := context.Background()
+	variants := []poc.Variant{
+		{},
+		{DrawCount: 3},
+		{Jokers: 2},
+		{Family: poc.Spider, Suits: 2},
+		{Family: poc.FreeCell},
+		{Family: poc.Pyramid},
+		{Family: poc.TriPeaks},
+	}
+	for _, variant := range variants {
+		for seed := int64(1); seed <= 3; seed++ {
+			random := rand.New(rand.NewSource(seed))
+			start, err := pipeline.StartGame{rules.Shuffle{random}, rules.NextMove{}}.CallStartGame(ctx, poc.StartGame{Variant: variant})
+			if err != nil {
+				t.Fatal(err)
+			}
+			game := start.SavedGameDetail
+			for step := 0; step < 100 && len(game.PossibleNextMoves) > 0; step++ {
+				next := game.PossibleNextMoves[random.Intn(len(game.PossibleNextMoves))]
+				move, err := pipeline.PerformMove{rules.Apply{}, rules.CheckIntegrity{}, rules.NextMove{}}.CallPerformMove(ctx, poc.PerformMove{SavedGameDetail: game, Next: next})
+				if err != nil {
+					t.Fatalf("variant %+v seed %d step %d: %s", variant, seed, step, err)
+				}
+				game = move.SavedGameDetail
+			}
+		}
+	}
+}
@@ -0,0 +1,261 @@
+package rules
+
+import (
+	"errors"
+
+	"github.com/slcjordan/poc"
+)
+
+// ErrJokers means wild jokers were asked for outside of Klondike or more than
+// the two a deck comes with.
+var ErrJokers = errors.New("klondike is played with 0, 1 or 2 jokers")
+
+// jokers checks the number of jokers in a variant.
+func jokers(variant poc.Variant) error {
+	switch {
+	case variant.Jokers == 0:
+		return nil
+	case variant.Family != poc.Klondike, variant.Jokers < 0, variant.Jokers > 2:
+		return ErrJokers
+	}
+	return nil
+}
+
+// jokerCards are the wild cards a deck comes with.
+var jokerCards = []poc.Card{
+	{Suit: poc.Joker, Index: poc.Juggler},
+	{Suit: poc.Joker, Index: poc.Fool},
+}
+
+func isJoker(card poc.Card) bool {
+	return card.Suit == poc.Joker
+}
+
+var (
+	redSuits   = []poc.Suit{poc.Hearts, poc.Diamonds}
+	blackSuits = []poc.Suit{poc.Clubs, poc.Spades}
+	allSuits   = []poc.Suit{poc.Hearts, poc.Clubs, poc.Diamonds, poc.Spades}
+)
+
+// wild is a card as far as building is concerned. Real cards are what they
+// are, but a joker stands in for whatever the cards around it need it to be.
+// Open jokers have nothing around them yet and could be any card.
+type wild struct {
+	open  bool
+	index poc.Index
+	suits []poc.Suit
+}
+
+// exactly is a real card as a wild.
+func exactly(card poc.Card) wild {
+	return wild{index: card.Index, suits: []poc.Suit{card.Suit}}
+}
+
+// is reports whether a real card is one of the cards w stands in for.
+func (w wild) is(card poc.Card) bool {
+	if w.open || card.Index != w.index {
+		return false
+	}
+	for _, suit := range w.suits {
+		if suit == card.Suit {
+			return true
+		}
+	}
+	return false
+}
+
+// takes reports whether card may be built onto w in the tableau.
+func (w wild) takes(card wild) bool {
+	switch {
+	case w.open:
+		return card.open || card.index < poc.King
+	case card.open:
+		return w.index > poc.Ace
+	case card.index != w.index-1:
+		return false
+	}
+	for _, below := range w.suits {
+		for _, above := range card.suits {
+			if isRed(poc.Card{Suit: below}) != isRed(poc.Card{Suit: above}) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// tableauWild works out what the card at pileIndex of a tableau pile is.
+// Face up tableau cards always form a run, so a joker takes its place in the
+// run from the nearest real card. A run that fills a pile under the Kings
+// only rule starts with a King.
+func tableauWild(pile []poc.PositionedCard, pileIndex int, fill poc.ColumnFill) wild {
+	card := pile[pileIndex].Card
+	if !isJoker(card) {
+		return exactly(card)
+	}
+	start := pileIndex
+	for start > 0 && pile[start-1].Position&poc.FaceUp != 0 {
+		start--
+	}
+	anchor, ok := -1, false
+	for i := start; i < len(pile); i++ {
+		if isJoker(pile[i].Card) {
+			continue
+		}
+		if !ok || abs(i-pileIndex) < abs(anchor-pileIndex) {
+			anchor, ok = i, true
+		}
+	}
+	var index poc.Index
+	var red, anyColour bool
+	switch {
+	case ok:
+		index = pile[anchor].Card.Index + poc.Index(anchor-pileIndex)
+		red = isRed(pile[anchor].Card) == ((anchor-pileIndex)%2 == 0)
+	case start == 0 && fill == poc.KingsOnlyFill:
+		index = poc.King - poc.Index(pileIndex)
+		anyColour = true
+	default:
+		return wild{open: true}
+	}
+	switch {
+	case anyColour:
+		return wild{index: index, suits: allSuits}
+	case red:
+		return wild{index: index, suits: redSuits}
+	}
+	return wild{index: index, suits: blackSuits}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// jokerMoves lists the Klondike moves that involve a joker. Jokers may be
+// built on and moved like any card they could stand in for, may go onto any
+// foundation as its next card and give up their place when the real card
+// they stand in for is played.
+func jokerMoves(game poc.SavedGameDetail) [][]poc.Move {
+	piles := game.Board.Piles
+	fill := game.Variant.EmptyColumnFill
+	var result [][]poc.Move
+
+	// whatIs works out what the card at the top of a pile from pileIndex up is.
+	whatIs := func(pileNum int, pileIndex int) wild {
+		if isTableau(pileNum) { // only the cards that move with it count
+			return tableauWild(piles[pileNum][pileIndex:], 0, poc.AnyCardFill)
+		}
+		card := piles[pileNum][pileIndex].Card
+		if isJoker(card) {
+			return wild{open: true}
+		}
+		return exactly(card)
+	}
+	// toTableau moves the cards of a pile from pileIndex up onto every tableau
+	// pile that takes them, as long as a joker is involved.
+	toTableau := func(pileNum int, pileIndex int) {
+		lead := piles[pileNum][pileIndex].Card
+		moving := whatIs(pileNum, pileIndex)
+		for dest := 2; dest < 9; dest++ {
+			if dest == pileNum {
+				continue
+			}
+			pile := piles[dest]
+			if len(pile) < 1 {
+				if !isJoker(lead) || (isTableau(pileNum) && pileIndex == 0) { // moving a whole pile into an empty one changes nothing
+					continue
+				}
+				if fill == poc.AnyCardFill || (fill == poc.KingsOnlyFill && (moving.open || moving.index == poc.King)) {
+					result = append(result, moveCards(piles, pileNum, pileIndex, dest))
+				}
+				continue
+			}
+			top := pile[len(pile)-1]
+			if top.Position&poc.FaceUp == 0 || (!isJoker(lead) && !isJoker(top.Card)) {
+				continue
+			}
+			if tableauWild(pile, len(pile)-1, fill).takes(moving) {
+				result = append(result, moveCards(piles, pileNum, pileIndex, dest))
+			}
+		}
+	}
+	// toFoundation moves a joker onto every foundation that isn't finished.
+	toFoundation := func(pileNum int) {
+		pile := piles[pileNum]
+		if !isJoker(pile[len(pile)-1].Card) {
+			return
+		}
+		for dest := 9; dest < 13; dest++ {
+			if len(piles[dest]) < 13 {
+				result = append(result, moveCards(piles, pileNum, len(pile)-1, dest))
+			}
+		}
+	}
+	// replace swaps the joker at pileIndex with a real card it stands in for
+	// from the top of the talon or tableau.
+	replace := func(pileNum int, pileIndex int, standsFor wild) {
+		joker := piles[pileNum][pileIndex]
+		for from := 1; from < 9; from++ {
+			pile := piles[from]
+			if from == pileNum || len(pile) < 1 {
+				continue
+			}
+			card := pile[len(pile)-1]
+			if card.Position&poc.FaceUp == 0 || !standsFor.is(card.Card) {
+				continue
+			}
+			result = append(result, []poc.Move{
+				{
+					OldPileNum:      from,
+					OldPileIndex:    len(pile) - 1,
+					OldPilePosition: card.Position,
+					NewPileNum:      pileNum,
+					NewPileIndex:    pileIndex,
+					NewPilePosition: joker.Position,
+				},
+				{
+					OldPileNum:      pileNum,
+					OldPileIndex:    pileIndex,
+					OldPilePosition: joker.Position,
+					NewPileNum:      from,
+					NewPileIndex:    len(pile) - 1,
+					NewPilePosition: card.Position,
+				},
+			})
+		}
+	}
+
+	if talon := piles[1]; len(talon) > 0 {
+		toTableau(1, len(talon)-1)
+		toFoundation(1)
+	}
+	for pileNum := 2; pileNum < 9; pileNum++ {
+		pile := piles[pileNum]
+		for pileIndex, card := range pile {
+			if card.Position&poc.FaceUp == 0 {
+				continue
+			}
+			toTableau(pileNum, pileIndex)
+			if isJoker(card.Card) {
+				if standsFor := tableauWild(pile, pileIndex, fill); !standsFor.open {
+					replace(pileNum, pileIndex, standsFor)
+				}
+			}
+		}
+		if len(pile) > 0 && pile[len(pile)-1].Position&poc.FaceUp != 0 {
+			toFoundation(pileNum)
+		}
+	}
+	for pileNum := 9; pileNum < 13; pileNum++ {
+		for pileIndex, card := range piles[pileNum] {
+			if isJoker(card.Card) {
+				suit := poc.Suit(pileNum - 8)
+				replace(pileNum, pileIndex, exactly(poc.Card{Suit: suit, Index: poc.Index(pileIndex) + poc.Ace}))
+			}
+		}
+	}
+	return result
+}
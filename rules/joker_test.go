@@ -0,0 +1,143 @@
+package rules_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/slcjordan/poc"
+	"github.com/slcjordan/poc/pipeline"
+	"github.com/slcjordan/poc/rules"
+	"github.com/slcjordan/poc/test/assert"
+	"github.com/slcjordan/poc/test/harness"
+	"github.com/slcjordan/poc/test/logger"
+)
+
+func TestJokers(t *testing.T) {
+	logger.RegisterVerbose(t)
+	wild := poc.Variant{Jokers: 2}
+	faceUp := func(suit poc.Suit, index poc.Index) poc.PositionedCard {
+		return poc.PositionedCard{Position: poc.FaceUp, Card: poc.Card{Suit: suit, Index: index}}
+	}
+	joker := faceUp(poc.Joker, poc.Juggler)
+
+	board := klondike() // a joker standing in for a red seven
+	board.Piles[1] = []poc.PositionedCard{faceUp(poc.Diamonds, poc.Seven)}
+	board.Piles[2] = []poc.PositionedCard{faceUp(poc.Spades, poc.Eight), joker}
+	board.Piles[3] = []poc.PositionedCard{faceUp(poc.Clubs, poc.Six)}
+	board.Piles[4] = []poc.PositionedCard{faceUp(poc.Hearts, poc.Six)}
+	board.Piles[9] = []poc.PositionedCard{faceUp(poc.Hearts, poc.Ace), joker}
+	board.Piles[5] = []poc.PositionedCard{faceUp(poc.Hearts, poc.Three)}
+	game := poc.SavedGameDetail{Board: board, Variant: wild}
+
+	talonJoker := klondike() // a joker nothing has been built around yet
+	talonJoker.Piles[1] = []poc.PositionedCard{joker}
+	talonJoker.Piles[2] = []poc.PositionedCard{faceUp(poc.Spades, poc.Eight)}
+
+	finished := klondike() // a joker stands in for the last King
+	for suit := poc.Hearts; suit <= poc.Spades; suit++ {
+		for index := poc.Ace; index <= poc.King; index++ {
+			card := faceUp(suit, index)
+			if suit == poc.Spades && index == poc.King {
+				card = joker
+			}
+			finished.Piles[suit+8] = append(finished.Piles[suit+8], card)
+		}
+	}
+	finished.Piles[2] = []poc.PositionedCard{faceUp(poc.Spades, poc.King)}
+	replaced := clone(finished) // the King has taken the joker's place
+	replaced.Piles[12] = append(finished.Piles[12][:12:12], faceUp(poc.Spades, poc.King))
+	replaced.Piles[2] = []poc.PositionedCard{joker}
+
+	harness.StartGame{
+		{
+			Desc:    "Jokers are shuffled into the deck",
+			Command: rules.Shuffle{rand.New(rand.NewSource(0))},
+			Input:   poc.StartGame{Variant: wild},
+			Result: assert.New().NoError().
+				StartGame.SavedGameDetail.Board.Piles.Nth(0).Length(assert.Equals(26)),
+		},
+		{
+			Desc:    "Only Klondike is played with jokers",
+			Command: rules.Shuffle{rand.New(rand.NewSource(0))},
+			Input:   poc.StartGame{Variant: poc.Variant{Family: poc.Spider, Jokers: 1}},
+			Result:  assert.New().Error.Category.Uint8(assert.Equals(poc.MalformedError)),
+		},
+		{
+			Desc:    "A joker standing in for a King on a foundation does not win",
+			Command: rules.Status{},
+			Input:   poc.StartGame{SavedGameDetail: poc.SavedGameDetail{Board: finished, Variant: wild}},
+			Result: assert.New().NoError().
+				StartGame.SavedGameDetail.Status.Uint8(assert.Equals(poc.InProgress)),
+		},
+		{
+			Desc:    "Replacing the joker with its King wins",
+			Command: rules.Status{},
+			Input:   poc.StartGame{SavedGameDetail: poc.SavedGameDetail{Board: replaced, Variant: wild}},
+			Result: assert.New().NoError().
+				StartGame.SavedGameDetail.Status.Uint8(assert.Equals(poc.Won)),
+		},
+	}.Run(t)
+
+	harness.PerformMove{
+		{
+			Desc:    "A joker can go on any card",
+			Command: rules.Validate{},
+			Input: poc.PerformMove{
+				Next: []poc.Move{
+					{OldPileNum: 1, OldPileIndex: 0, OldPilePosition: poc.FaceUp, NewPileNum: 2, NewPileIndex: 1, NewPilePosition: poc.FaceUp},
+				},
+				SavedGameDetail: poc.SavedGameDetail{Board: talonJoker, Variant: wild},
+			},
+			Result: assert.New().NoError(),
+		},
+		{
+			Desc:    "Build on a joker like the card it stands in for",
+			Command: rules.Validate{},
+			Input: poc.PerformMove{
+				Next: []poc.Move{
+					{OldPileNum: 3, OldPileIndex: 0, OldPilePosition: poc.FaceUp, NewPileNum: 2, NewPileIndex: 2, NewPilePosition: poc.FaceUp},
+				},
+				SavedGameDetail: game,
+			},
+			Result: assert.New().NoError(),
+		},
+		{
+			Desc:    "A joker standing in for a red card takes a black card",
+			Command: rules.Validate{},
+			Input: poc.PerformMove{
+				Next: []poc.Move{
+					{OldPileNum: 4, OldPileIndex: 0, OldPilePosition: poc.FaceUp, NewPileNum: 2, NewPileIndex: 2, NewPilePosition: poc.FaceUp},
+				},
+				SavedGameDetail: game,
+			},
+			Result: assert.New().Error.Category.Uint8(assert.Equals(poc.SemanticError)),
+		},
+		{
+			Desc:    "The real card replaces the joker",
+			Command: pipeline.PerformMove{rules.Validate{}, rules.Apply{}},
+			Input: poc.PerformMove{
+				Next: []poc.Move{
+					{OldPileNum: 1, OldPileIndex: 0, OldPilePosition: poc.FaceUp, NewPileNum: 2, NewPileIndex: 1, NewPilePosition: poc.FaceUp},
+					{OldPileNum: 2, OldPileIndex: 1, OldPilePosition: poc.FaceUp, NewPileNum: 1, NewPileIndex: 0, NewPilePosition: poc.FaceUp},
+				},
+				SavedGameDetail: game,
+			},
+			Result: func() *assert.Assertion {
+				a := assert.New().NoError()
+				a.PerformMove.SavedGameDetail.Board.Piles.Nth(2).Nth(1).Card.Index.Uint8(assert.Equals(poc.Seven))
+				return a.PerformMove.SavedGameDetail.Board.Piles.Nth(1).Nth(0).Card.Suit.Uint8(assert.Equals(poc.Joker))
+			}(),
+		},
+		{
+			Desc:    "A joker on a foundation stands in for its next card",
+			Command: rules.Validate{},
+			Input: poc.PerformMove{
+				Next: []poc.Move{
+					{OldPileNum: 5, OldPileIndex: 0, OldPilePosition: poc.FaceUp, NewPileNum: 9, NewPileIndex: 2, NewPilePosition: poc.FaceUp},
+				},
+				SavedGameDetail: game,
+			},
+			Result: assert.New().NoError(),
+		},
+	}.Run(t)
+}
@@ -0,0 +1,120 @@
+package rules
+
+import (
+	"github.com/slcjordan/poc"
+)
+
+// klondikePiles is the number of piles in a Klondike game: the stock, the
+// talon, seven tableau piles and four foundations.
+const klondikePiles = 13
+
+func init() {
+	Register("klondike", klondike{})
+}
+
+// klondike is the Klondike ruleset.
+type klondike struct{}
+
+func (k klondike) Piles(variant poc.Variant) int {
+	return klondikePiles
+}
+
+func (k klondike) Seed(random int64) int64 {
+	return random
+}
+
+func (k klondike) Setup(variant poc.Variant, seed int64) (poc.Variant, error) {
+	return variant, nil
+}
+
+func (k klondike) Shuffle(variant poc.Variant, seed int64) []poc.PositionedCard {
+	return fisherYates(deck(variant), seed)
+}
+
+// Deal deals seven tableau piles of one to seven cards and leaves the rest in
+// the stock.
+func (k klondike) Deal(variant poc.Variant, cards []poc.PositionedCard) poc.Board {
+	piles := make([][]poc.PositionedCard, klondikePiles)
+	piles[8] = cards[21:28]
+	piles[7] = cards[15:21]
+	piles[6] = cards[10:15]
+	piles[5] = cards[6:10]
+	piles[4] = cards[3:6]
+	piles[3] = cards[1:3]
+	piles[2] = cards[:1]
+	piles[0] = cards[28:]
+	return poc.Board{Piles: piles}
+}
+
+func (k klondike) Moves(game poc.SavedGameDetail) [][]poc.Move {
+	return klondikeMoves(game)
+}
+
+// Validate checks the piles a move group moves between. Jokers stand in for
+// whatever the cards around them need, so moves the direct check turns down
+// are looked for among the joker moves.
+func (k klondike) Validate(game poc.SavedGameDetail, moves []poc.Move) error {
+	err := klondikeCheck(game, moves)
+	if err != nil && game.Variant.Jokers > 0 && search(jokerMoves(game), moves) == nil {
+		return nil
+	}
+	return err
+}
+
+// Start charges for the deal in Vegas scoring.
+func (k klondike) Start(variant poc.Variant) int32 {
+	if variant.Scoring == poc.VegasScoring {
+		return vegasDeal
+	}
+	return 0
+}
+
+func (k klondike) Points(variant poc.Variant, moves []poc.Move) int32 {
+	return klondikePoints(variant, moves)
+}
+
+// Won reports whether every foundation has been built up to its King with
+// real cards. A joker on a foundation has to be replaced by the card it stands
+// in for first.
+func (k klondike) Won(game poc.SavedGameDetail) bool {
+	for _, pile := range game.Board.Piles[9:] {
+		if len(pile) < 13 {
+			return false
+		}
+		for _, card := range pile {
+			if isJoker(card.Card) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Stuck reports whether the only moves left cycle the stock or shuffle face up
+// cards between the tableau and foundations.
+func (k klondike) Stuck(game poc.SavedGameDetail, limit int) bool {
+	return goingNowhere(game, isShuffle, limit)
+}
+
+// CheckIntegrity checks that the stock is face down, the talon and
+// foundations are face up, and each foundation is built up from its Ace in
+// its own suit.
+func (k klondike) CheckIntegrity(game poc.SavedGameDetail) error {
+	for pileNum, pile := range game.Board.Piles {
+		for pileIndex, card := range pile {
+			faceUp := card.Position&poc.FaceUp != 0
+			switch {
+			case pileNum == 0 && faceUp:
+				return corrupt("the stock has a face up card at index %d", pileIndex)
+			case pileNum == 1 && !faceUp:
+				return corrupt("the talon has a face down card at index %d", pileIndex)
+			case !isFoundation(pileNum) || isJoker(card.Card): // jokers may stand in anywhere
+			case !faceUp:
+				return corrupt("foundation %d has a face down card at index %d", pileNum, pileIndex)
+			case card.Card.Suit+8 != poc.Suit(pileNum) || card.Card.Index != poc.Index(pileIndex)+poc.Ace:
+				return corrupt("foundation %d holds the %s of %s at index %d", pileNum, card.Card.Index, card.Card.Suit, pileIndex)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,226 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/slcjordan/poc"
+)
+
+// Reason is why a move is not allowed.
+//go:generate stringer -type=Reason
+type Reason uint8
+
+// Reasons a move may not be allowed.
+const (
+	_ Reason = iota
+	NotAllowed
+	BadIndex
+	BadPosition
+	FaceDown
+	WrongColor
+	WrongRank
+	WrongSuit
+)
+
+// IllegalMove is an invalid move and the reason it was turned down. It wraps
+// ErrInvalidMove.
+type IllegalMove struct {
+	Reason Reason
+	Move   poc.Move
+}
+
+func (e IllegalMove) Unwrap() error {
+	return ErrInvalidMove
+}
+
+func (e IllegalMove) Error() string {
+	return fmt.Sprintf(
+		"%s (%s): pile %d index %d onto pile %d index %d",
+		ErrInvalidMove, e.Reason, e.Move.OldPileNum, e.Move.OldPileIndex, e.Move.NewPileNum, e.Move.NewPileIndex,
+	)
+}
+
+func illegal(reason Reason, move poc.Move) error {
+	return IllegalMove{Reason: reason, Move: move}
+}
+
+// klondikeCheck checks a Klondike move group against the piles it moves
+// between instead of listing every possible move. Moves may be given in any
+// order. Every kind of move picks up the cards of a pile from some index up
+// to the top, so that is checked first.
+func klondikeCheck(game poc.SavedGameDetail, moves []poc.Move) error {
+	if len(moves) < 1 {
+		return illegal(NotAllowed, poc.Move{})
+	}
+	piles := game.Board.Piles
+	first := moves[0]
+	lead := first // the bottom card of the cards being moved
+	for _, m := range moves {
+		if m.OldPileNum != first.OldPileNum || m.NewPileNum != first.NewPileNum {
+			return illegal(NotAllowed, m)
+		}
+		if m.OldPileNum < 0 || m.OldPileNum >= len(piles) || m.NewPileNum < 0 || m.NewPileNum >= len(piles) ||
+			m.OldPileIndex < 0 || m.OldPileIndex >= len(piles[m.OldPileNum]) {
+			return illegal(BadIndex, m)
+		}
+		if piles[m.OldPileNum][m.OldPileIndex].Position != m.OldPilePosition {
+			return illegal(BadPosition, m)
+		}
+		if m.OldPileIndex < lead.OldPileIndex {
+			lead = m
+		}
+	}
+	from := piles[first.OldPileNum]
+	if lead.OldPileIndex+len(moves) != len(from) {
+		return illegal(BadIndex, lead)
+	}
+	seen := make([]bool, len(moves))
+	for _, m := range moves {
+		if seen[m.OldPileIndex-lead.OldPileIndex] {
+			return illegal(BadIndex, m)
+		}
+		seen[m.OldPileIndex-lead.OldPileIndex] = true
+	}
+
+	switch {
+	case first.OldPileNum == first.NewPileNum:
+		return checkFlip(game, moves)
+	case first.OldPileNum == 0:
+		return checkDraw(game, moves)
+	case first.NewPileNum == 0:
+		return checkRecycle(game, moves)
+	case isFoundation(first.NewPileNum):
+		return checkToFoundation(game, moves)
+	case isTableau(first.NewPileNum):
+		return checkToTableau(game, moves, lead)
+	}
+	return illegal(NotAllowed, first)
+}
+
+// checkFlip checks turning over the top card of a tableau pile.
+func checkFlip(game poc.SavedGameDetail, moves []poc.Move) error {
+	m := moves[0]
+	switch {
+	case len(moves) > 1 || !isTableau(m.OldPileNum) || m.OldPilePosition&poc.FaceUp != 0:
+		return illegal(NotAllowed, m)
+	case m.NewPileIndex != m.OldPileIndex:
+		return illegal(BadIndex, m)
+	case m.NewPilePosition != m.OldPilePosition|poc.FaceUp:
+		return illegal(BadPosition, m)
+	}
+	return nil
+}
+
+// checkDraw checks drawing Variant.DrawCount cards from the stock onto the
+// talon.
+func checkDraw(game poc.SavedGameDetail, moves []poc.Move) error {
+	stock, talon := game.Board.Piles[0], game.Board.Piles[1]
+	drawCount := int(game.Variant.DrawCount)
+	if drawCount < 1 {
+		drawCount = 1
+	}
+	if drawCount > len(stock) {
+		drawCount = len(stock)
+	}
+	for _, m := range moves {
+		switch {
+		case m.NewPileNum != 1:
+			return illegal(NotAllowed, m)
+		case len(moves) != drawCount || m.NewPileIndex != len(talon)+len(stock)-1-m.OldPileIndex:
+			return illegal(BadIndex, m)
+		case m.NewPilePosition != m.OldPilePosition|poc.FaceUp:
+			return illegal(BadPosition, m)
+		}
+	}
+	return nil
+}
+
+// checkRecycle checks turning the whole talon back over onto an empty stock.
+func checkRecycle(game poc.SavedGameDetail, moves []poc.Move) error {
+	stock, talon := game.Board.Piles[0], game.Board.Piles[1]
+	for _, m := range moves {
+		switch {
+		case m.OldPileNum != 1 || len(stock) > 0 || !canRecycle(game):
+			return illegal(NotAllowed, m)
+		case len(moves) != len(talon) || m.NewPileIndex != len(talon)-1-m.OldPileIndex:
+			return illegal(BadIndex, m)
+		case m.NewPilePosition != talon[len(talon)-1].Position&^poc.FaceUp:
+			return illegal(BadPosition, m)
+		}
+	}
+	return nil
+}
+
+// checkToFoundation checks playing the top card of the talon or a tableau
+// pile onto its foundation.
+func checkToFoundation(game poc.SavedGameDetail, moves []poc.Move) error {
+	m := moves[0]
+	card := game.Board.Piles[m.OldPileNum][m.OldPileIndex]
+	dest := game.Board.Piles[m.NewPileNum]
+	switch {
+	case len(moves) > 1 || isFoundation(m.OldPileNum) || isJoker(card.Card):
+		return illegal(NotAllowed, m)
+	case card.Position&poc.FaceUp == 0:
+		return illegal(FaceDown, m)
+	case m.NewPileNum != int(card.Card.Suit+8):
+		return illegal(WrongSuit, m)
+	case card.Card.Index != poc.Index(len(dest))+poc.Ace:
+		return illegal(WrongRank, m)
+	case m.NewPileIndex != len(dest):
+		return illegal(BadIndex, m)
+	case m.NewPilePosition != m.OldPilePosition:
+		return illegal(BadPosition, m)
+	}
+	return nil
+}
+
+// checkToTableau checks building cards onto a tableau pile. The talon and
+// foundations give up their top card and tableau piles a run of face up cards
+// starting at lead.
+func checkToTableau(game poc.SavedGameDetail, moves []poc.Move, lead poc.Move) error {
+	piles := game.Board.Piles
+	card := piles[lead.OldPileNum][lead.OldPileIndex]
+	dest := piles[lead.NewPileNum]
+	if len(moves) > 1 && !isTableau(lead.OldPileNum) {
+		return illegal(NotAllowed, lead)
+	}
+	for _, m := range moves {
+		switch {
+		case m.OldPilePosition&poc.FaceUp == 0:
+			return illegal(FaceDown, m)
+		case m.NewPileIndex != len(dest)+m.OldPileIndex-lead.OldPileIndex:
+			return illegal(BadIndex, m)
+		case m.NewPilePosition != m.OldPilePosition|poc.FaceUp:
+			return illegal(BadPosition, m)
+		}
+	}
+	if isJoker(card.Card) {
+		return illegal(NotAllowed, lead)
+	}
+	if len(dest) < 1 {
+		if isTableau(lead.OldPileNum) && lead.OldPileIndex == 0 { // moving a whole pile into an empty one changes nothing
+			return illegal(NotAllowed, lead)
+		}
+		switch game.Variant.EmptyColumnFill {
+		case poc.NoCardFill:
+			return illegal(NotAllowed, lead)
+		case poc.KingsOnlyFill:
+			if card.Card.Index != poc.King {
+				return illegal(WrongRank, lead)
+			}
+		}
+		return nil
+	}
+	top := dest[len(dest)-1]
+	switch {
+	case top.Position&poc.FaceUp == 0:
+		return illegal(FaceDown, lead)
+	case isJoker(top.Card):
+		return illegal(NotAllowed, lead)
+	case top.Card.Index != card.Card.Index+1:
+		return illegal(WrongRank, lead)
+	case isRed(top.Card) == isRed(card.Card):
+		return illegal(WrongColor, lead)
+	}
+	return nil
+}
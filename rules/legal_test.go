@@ -0,0 +1,201 @@
+package rules_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/slcjordan/poc"
+	"github.com/slcjordan/poc/pipeline"
+	"github.com/slcjordan/poc/rules"
+)
+
+// moveKey names a move group the same way whatever order its moves are in.
+func moveKey(moves []poc.Move) string {
+	keys := make([]string, len(moves))
+	for i, m := range moves {
+		keys[i] = fmt.Sprint(m)
+	}
+	sort.Strings(keys)
+	return fmt.Sprint(keys)
+}
+
+// TestValidateMatchesNextMove plays random games and checks that Validate
+// accepts exactly the move groups NextMove lists, in any order.
+func TestValidateMatchesNextMove(t *testing.T) {
+	ctx := context.Background()
+	variants := []poc.Variant{
+		{},
+		{DrawCount: 3, MaxTimesThroughDeck: 3},
+		{EmptyColumnFill: poc.AnyCardFill},
+		{Jokers: 2},
+	}
+	for _, variant := range variants {
+		for seed := int64(1); seed <= 5; seed++ {
+			random := rand.New(rand.NewSource(seed))
+			start, err := pipeline.StartGame{rules.Shuffle{random}, rules.NextMove{}}.CallStartGame(ctx, poc.StartGame{Variant: variant})
+			if err != nil {
+				t.Fatal(err)
+			}
+			game := start.SavedGameDetail
+			for step := 0; step < 60 && len(game.PossibleNextMoves) > 0; step++ {
+				possible := make(map[string]bool)
+				for _, moves := range game.PossibleNextMoves {
+					possible[moveKey(moves)] = true
+				}
+				for _, moves := range game.PossibleNextMoves {
+					reversed := make([]poc.Move, len(moves))
+					for i, m := range moves {
+						reversed[len(moves)-1-i] = m
+					}
+					_, err := rules.Validate{}.CallPerformMove(ctx, poc.PerformMove{SavedGameDetail: game, Next: reversed})
+					if err != nil {
+						t.Fatalf("variant %+v seed %d step %d: %v was turned down: %s", variant, seed, step, moves, err)
+					}
+					for newPileNum := range game.Board.Piles { // the same cards moved anywhere else
+						moved := make([]poc.Move, len(moves))
+						for i, m := range moves {
+							m.NewPileNum = newPileNum
+							moved[i] = m
+						}
+						_, err := rules.Validate{}.CallPerformMove(ctx, poc.PerformMove{SavedGameDetail: game, Next: moved})
+						if (err == nil) != possible[moveKey(moved)] {
+							t.Fatalf("variant %+v seed %d step %d: %v gave %v", variant, seed, step, moved, err)
+						}
+					}
+				}
+				next := game.PossibleNextMoves[random.Intn(len(game.PossibleNextMoves))]
+				move, err := pipeline.PerformMove{rules.Apply{}, rules.NextMove{}}.CallPerformMove(ctx, poc.PerformMove{SavedGameDetail: game, Next: next})
+				if err != nil {
+					t.Fatal(err)
+				}
+				game = move.SavedGameDetail
+			}
+		}
+	}
+}
+
+func TestIllegalMove(t *testing.T) {
+	faceUp := func(suit poc.Suit, index poc.Index) poc.PositionedCard {
+		return poc.PositionedCard{Position: poc.FaceUp, Card: poc.Card{Suit: suit, Index: index}}
+	}
+	board := klondike()
+	board.Piles[1] = []poc.PositionedCard{faceUp(poc.Hearts, poc.Two)}
+	board.Piles[2] = []poc.PositionedCard{faceUp(poc.Spades, poc.Eight)}
+	board.Piles[3] = []poc.PositionedCard{faceUp(poc.Clubs, poc.Seven)}
+	board.Piles[4] = []poc.PositionedCard{faceUp(poc.Diamonds, poc.Six)}
+	board.Piles[5] = []poc.PositionedCard{{Card: poc.Card{Suit: poc.Hearts, Index: poc.Nine}}}
+	board.Piles[9] = []poc.PositionedCard{faceUp(poc.Hearts, poc.Ace)}
+
+	for _, testCase := range []struct {
+		Desc   string
+		Move   poc.Move
+		Reason rules.Reason
+	}{
+		{
+			Desc:   "Colors must alternate",
+			Move:   poc.Move{OldPileNum: 3, OldPilePosition: poc.FaceUp, NewPileNum: 2, NewPileIndex: 1, NewPilePosition: poc.FaceUp},
+			Reason: rules.WrongColor,
+		},
+		{
+			Desc:   "Cards must go down by one",
+			Move:   poc.Move{OldPileNum: 4, OldPilePosition: poc.FaceUp, NewPileNum: 2, NewPileIndex: 1, NewPilePosition: poc.FaceUp},
+			Reason: rules.WrongRank,
+		},
+		{
+			Desc:   "Face down cards can't be built on",
+			Move:   poc.Move{OldPileNum: 2, OldPilePosition: poc.FaceUp, NewPileNum: 5, NewPileIndex: 1, NewPilePosition: poc.FaceUp},
+			Reason: rules.FaceDown,
+		},
+		{
+			Desc:   "Cards go on top of the pile",
+			Move:   poc.Move{OldPileNum: 1, OldPilePosition: poc.FaceUp, NewPileNum: 9, NewPileIndex: 0, NewPilePosition: poc.FaceUp},
+			Reason: rules.BadIndex,
+		},
+		{
+			Desc:   "Only cards that are there can be moved",
+			Move:   poc.Move{OldPileNum: 6, OldPilePosition: poc.FaceUp, NewPileNum: 2, NewPileIndex: 1, NewPilePosition: poc.FaceUp},
+			Reason: rules.BadIndex,
+		},
+		{
+			Desc:   "Foundations are built by suit",
+			Move:   poc.Move{OldPileNum: 1, OldPilePosition: poc.FaceUp, NewPileNum: 10, NewPileIndex: 0, NewPilePosition: poc.FaceUp},
+			Reason: rules.WrongSuit,
+		},
+	} {
+		t.Run(testCase.Desc, func(t *testing.T) {
+			_, err := rules.Validate{}.CallPerformMove(context.Background(), poc.PerformMove{
+				Next:            []poc.Move{testCase.Move},
+				SavedGameDetail: poc.SavedGameDetail{Board: board},
+			})
+			var illegal rules.IllegalMove
+			if !errors.As(err, &illegal) {
+				t.Fatalf("expected an illegal move but got %v", err)
+			}
+			if illegal.Reason != testCase.Reason {
+				t.Errorf("expected %s but got %s", testCase.Reason, illegal.Reason)
+			}
+			if !errors.Is(err, rules.ErrInvalidMove) {
+				t.Errorf("expected %v to be an invalid move", err)
+			}
+		})
+	}
+}
+
+// deepTableau is a late Klondike position: every tableau pile holds a long
+// run on top of face down cards and the talon is full.
+func deepTableau() poc.SavedGameDetail {
+	board := klondike()
+	suits := [][]poc.Suit{{poc.Spades, poc.Hearts}, {poc.Hearts, poc.Clubs}, {poc.Clubs, poc.Diamonds}, {poc.Diamonds, poc.Spades}}
+	for pileNum := 2; pileNum < 9; pileNum++ {
+		for i := 0; i < pileNum; i++ {
+			board.Piles[pileNum] = append(board.Piles[pileNum], poc.PositionedCard{Card: poc.Card{Suit: poc.Spades, Index: poc.Two}})
+		}
+		colors := suits[pileNum%4]
+		for index := poc.King; index >= poc.Two; index-- {
+			board.Piles[pileNum] = append(board.Piles[pileNum], poc.PositionedCard{
+				Position: poc.FaceUp,
+				Card:     poc.Card{Suit: colors[int(index)%2], Index: index},
+			})
+		}
+	}
+	for i := 0; i < 20; i++ {
+		board.Piles[1] = append(board.Piles[1], poc.PositionedCard{Position: poc.FaceUp, Card: poc.Card{Suit: poc.Hearts, Index: poc.Ace}})
+	}
+	board.Piles[9] = []poc.PositionedCard{{Position: poc.FaceUp, Card: poc.Card{Suit: poc.Hearts, Index: poc.Ace}}}
+	return poc.SavedGameDetail{Board: board, Variant: poc.Variant{EmptyColumnFill: poc.AnyCardFill}}
+}
+
+// BenchmarkValidate compares the direct check against listing every possible
+// move and searching the sorted list for the move, which is how Klondike moves
+// used to be validated.
+func BenchmarkValidate(b *testing.B) {
+	ctx := context.Background()
+	game := deepTableau()
+	next, err := rules.NextMove{}.CallLookupGame(ctx, poc.LookupGame{SavedGameDetail: game})
+	if err != nil {
+		b.Fatal(err)
+	}
+	possible := next.SavedGameDetail.PossibleNextMoves
+	move := possible[len(possible)/2]
+
+	b.Run("direct", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, err := rules.Validate{}.CallPerformMove(ctx, poc.PerformMove{SavedGameDetail: game, Next: move})
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("search", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			err := rules.ValidateBySearch(game, move)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,176 @@
+package rules
+
+import (
+	"github.com/slcjordan/poc"
+)
+
+// Pyramid piles: the stock, the talon, the 28 slots of the pyramid from the
+// top row down and a foundation for every card that has been removed.
+const (
+	pyramidTableau    = 2
+	pyramidFoundation = 30
+	pyramidPiles      = 31
+)
+
+// Pyramid points.
+const (
+	pyramidCard    = 5
+	pyramidCleared = 25
+)
+
+// pyramidSlots lays out seven rows of one to seven cards, each row
+// overlapping the one above it.
+func pyramidSlots() []poc.Slot {
+	var slots []poc.Slot
+	for row := 0; row < 7; row++ {
+		for col := 0; col <= row; col++ {
+			slots = append(slots, poc.Slot{
+				PileNum: pyramidTableau + len(slots),
+				Row:     row,
+				Column:  6 - row + 2*col,
+			})
+		}
+	}
+	return slots
+}
+
+// pyramidDeal deals 28 cards face up into the pyramid and leaves the rest in
+// the stock.
+func pyramidDeal(cards []poc.PositionedCard) [][]poc.PositionedCard {
+	piles := make([][]poc.PositionedCard, pyramidPiles)
+	for i, card := range cards[:28] {
+		card.Position |= poc.FaceUp
+		piles[pyramidTableau+i] = []poc.PositionedCard{card}
+	}
+	piles[0] = cards[28:]
+	return piles
+}
+
+// pyramidMoves lists the moves of a Pyramid game. Two uncovered cards that
+// add up to 13 are removed together and Kings are removed on their own. The
+// top of the talon counts as uncovered.
+func pyramidMoves(game poc.SavedGameDetail) [][]poc.Move {
+	piles := game.Board.Piles
+	var result [][]poc.Move
+
+	var free []int // piles whose top card may be removed
+	if len(piles[1]) > 0 {
+		free = append(free, 1)
+	}
+	for pileNum := pyramidTableau; pileNum < pyramidFoundation; pileNum++ {
+		if len(piles[pileNum]) > 0 && !covered(game.Board, pileNum) {
+			free = append(free, pileNum)
+		}
+	}
+	// remove moves the top card of a pile onto the next free foundation index.
+	remove := func(pileNum int, next int) poc.Move {
+		pile := piles[pileNum]
+		return poc.Move{
+			OldPileNum:      pileNum,
+			OldPileIndex:    len(pile) - 1,
+			OldPilePosition: pile[len(pile)-1].Position,
+			NewPileNum:      pyramidFoundation,
+			NewPileIndex:    len(piles[pyramidFoundation]) + next,
+			NewPilePosition: pile[len(pile)-1].Position,
+		}
+	}
+	for i, pileNum := range free {
+		card := piles[pileNum][len(piles[pileNum])-1].Card
+		if card.Index == poc.King {
+			result = append(result, []poc.Move{remove(pileNum, 0)})
+			continue
+		}
+		for _, other := range free[i+1:] {
+			pair := piles[other][len(piles[other])-1].Card
+			if card.Index+pair.Index == 13 {
+				result = append(result, []poc.Move{remove(pileNum, 0), remove(other, 1)})
+			}
+		}
+	}
+
+	if len(piles[0]) > 0 {
+		result = append(result, drawCard(piles))
+	} else if len(piles[1]) > 0 && canRecycle(game) {
+		result = append(result, recycleTalon(piles))
+	}
+	return result
+}
+
+// pyramidPoints scores a Pyramid move group. Every card removed earns 5
+// points and clearing the top of the pyramid earns 25 more.
+func pyramidPoints(moves []poc.Move) int32 {
+	var result int32
+	for _, m := range moves {
+		if m.NewPileNum != pyramidFoundation {
+			continue
+		}
+		result += pyramidCard
+		if m.OldPileNum == pyramidTableau {
+			result += pyramidCleared
+		}
+	}
+	return result
+}
+
+// pyramidWon reports whether the pyramid has been cleared.
+func pyramidWon(game poc.SavedGameDetail) bool {
+	for _, pile := range game.Board.Piles[pyramidTableau:pyramidFoundation] {
+		if len(pile) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func init() {
+	Register("pyramid", pyramid{})
+}
+
+// pyramid is the Pyramid ruleset.
+type pyramid struct{}
+
+func (p pyramid) Piles(variant poc.Variant) int {
+	return pyramidPiles
+}
+
+func (p pyramid) Seed(random int64) int64 {
+	return random
+}
+
+func (p pyramid) Setup(variant poc.Variant, seed int64) (poc.Variant, error) {
+	return variant, nil
+}
+
+func (p pyramid) Shuffle(variant poc.Variant, seed int64) []poc.PositionedCard {
+	return fisherYates(deck(variant), seed)
+}
+
+// Deal deals the cards into overlapping slots.
+func (p pyramid) Deal(variant poc.Variant, cards []poc.PositionedCard) poc.Board {
+	slots := pyramidSlots()
+	return poc.Board{Piles: pyramidDeal(cards), Slots: slots, Covers: coverRows(slots)}
+}
+
+func (p pyramid) Moves(game poc.SavedGameDetail) [][]poc.Move {
+	return pyramidMoves(game)
+}
+
+func (p pyramid) Validate(game poc.SavedGameDetail, moves []poc.Move) error {
+	return search(p.Moves(game), moves)
+}
+
+func (p pyramid) Start(variant poc.Variant) int32 {
+	return 0
+}
+
+func (p pyramid) Points(variant poc.Variant, moves []poc.Move) int32 {
+	return pyramidPoints(moves)
+}
+
+func (p pyramid) Won(game poc.SavedGameDetail) bool {
+	return pyramidWon(game)
+}
+
+func (p pyramid) Stuck(game poc.SavedGameDetail, limit int) bool {
+	return stockStuck(game, limit)
+}
@@ -0,0 +1,112 @@
+package rules_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/slcjordan/poc"
+	"github.com/slcjordan/poc/rules"
+	"github.com/slcjordan/poc/test/assert"
+	"github.com/slcjordan/poc/test/harness"
+	"github.com/slcjordan/poc/test/logger"
+)
+
+func TestPyramid(t *testing.T) {
+	logger.RegisterVerbose(t)
+	pyramid := poc.Variant{Family: poc.Pyramid}
+	faceUp := func(suit poc.Suit, index poc.Index) poc.PositionedCard {
+		return poc.PositionedCard{Position: poc.FaceUp, Card: poc.Card{Suit: suit, Index: index}}
+	}
+
+	board := poc.Board{Piles: make([][]poc.PositionedCard, 31)} // a King covered by a pair that adds up to 13
+	board.Piles[22] = []poc.PositionedCard{faceUp(poc.Clubs, poc.King)}
+	board.Piles[28] = []poc.PositionedCard{faceUp(poc.Spades, poc.Seven)}
+	board.Piles[29] = []poc.PositionedCard{faceUp(poc.Hearts, poc.Six)}
+	board.Covers = []poc.Cover{{PileNum: 22, CoveredBy: 28}, {PileNum: 22, CoveredBy: 29}}
+	pair := []poc.Move{
+		{OldPileNum: 28, OldPileIndex: 0, OldPilePosition: poc.FaceUp, NewPileNum: 30, NewPileIndex: 0, NewPilePosition: poc.FaceUp},
+		{OldPileNum: 29, OldPileIndex: 0, OldPilePosition: poc.FaceUp, NewPileNum: 30, NewPileIndex: 1, NewPilePosition: poc.FaceUp},
+	}
+	king := []poc.Move{
+		{OldPileNum: 22, OldPileIndex: 0, OldPilePosition: poc.FaceUp, NewPileNum: 30, NewPileIndex: 2, NewPilePosition: poc.FaceUp},
+	}
+	paired := clone(board)
+	paired.Piles[28] = nil
+	paired.Piles[29] = nil
+	paired.Piles[30] = append(board.Piles[28], board.Piles[29]...)
+	cleared := poc.Board{Piles: make([][]poc.PositionedCard, 31)}
+
+	harness.StartGame{
+		{
+			Desc:    "Deal a pyramid of 28 cards",
+			Command: rules.Shuffle{rand.New(rand.NewSource(0))},
+			Input:   poc.StartGame{Variant: pyramid},
+			Result: func() *assert.Assertion {
+				a := assert.New().NoError()
+				a.StartGame.SavedGameDetail.Board.Piles.Length(assert.Equals(31))
+				a.StartGame.SavedGameDetail.Board.Piles.Nth(0).Length(assert.Equals(24))
+				a.StartGame.SavedGameDetail.Board.Piles.Nth(2).Nth(0).Position.Uint64(assert.Equals(poc.FaceUp))
+				a.StartGame.SavedGameDetail.Board.Slots.Length(assert.Equals(28))
+				a.StartGame.SavedGameDetail.Board.Slots.Nth(27).Row(assert.Equals(6))
+				a.StartGame.SavedGameDetail.Board.Slots.Nth(27).Column(assert.Equals(12))
+				return a.StartGame.SavedGameDetail.Board.Covers.Length(assert.Equals(42))
+			}(),
+		},
+		{
+			Desc:    "Only uncovered cards are paired",
+			Command: rules.NextMove{},
+			Input:   poc.StartGame{SavedGameDetail: poc.SavedGameDetail{Board: board, Variant: pyramid}},
+			Result: func() *assert.Assertion {
+				a := assert.New().NoError()
+				a.StartGame.SavedGameDetail.PossibleNextMoves.Length(assert.Equals(1))
+				return a.StartGame.SavedGameDetail.PossibleNextMoves.Nth(0).Length(assert.Equals(2))
+			}(),
+		},
+		{
+			Desc:    "The pyramid is cleared",
+			Command: rules.Status{},
+			Input:   poc.StartGame{SavedGameDetail: poc.SavedGameDetail{Board: cleared, Variant: pyramid}},
+			Result: assert.New().NoError().
+				StartGame.SavedGameDetail.Status.Uint8(assert.Equals(poc.Won)),
+		},
+	}.Run(t)
+
+	harness.PerformMove{
+		{
+			Desc:    "Pair cards that add up to 13",
+			Command: rules.Validate{},
+			Input: poc.PerformMove{
+				Next:            pair,
+				SavedGameDetail: poc.SavedGameDetail{Board: board, Variant: pyramid},
+			},
+			Result: assert.New().NoError(),
+		},
+		{
+			Desc:    "A covered King can't be removed",
+			Command: rules.Validate{},
+			Input: poc.PerformMove{
+				Next:            king,
+				SavedGameDetail: poc.SavedGameDetail{Board: board, Variant: pyramid},
+			},
+			Result: assert.New().Error.Category.Uint8(assert.Equals(poc.SemanticError)),
+		},
+		{
+			Desc:    "An uncovered King is removed on its own",
+			Command: rules.Validate{},
+			Input: poc.PerformMove{
+				Next:            king,
+				SavedGameDetail: poc.SavedGameDetail{Board: paired, Variant: pyramid},
+			},
+			Result: assert.New().NoError(),
+		},
+		{
+			Desc:    "Every card removed earns 5 points",
+			Command: rules.Score{},
+			Input: poc.PerformMove{
+				Next:            pair,
+				SavedGameDetail: poc.SavedGameDetail{Variant: pyramid},
+			},
+			Result: assert.New().NoError().PerformMove.SavedGameDetail.Board.Score(assert.Equals(10)),
+		},
+	}.Run(t)
+}
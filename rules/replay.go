@@ -0,0 +1,70 @@
+package rules
+
+import (
+	"context"
+	"errors"
+
+	"github.com/slcjordan/poc"
+)
+
+// ErrNoSuchMove means a replay asked for a move number past the end of the
+// history.
+var ErrNoSuchMove = errors.New("no such move")
+
+// Replay folds a game's History onto its dealt board.
+type Replay struct{}
+
+// CallReplay deals the game again from its seed and remakes each move group
+// up to game.MoveNumber. Every move group is validated, applied and scored
+// the way it was when it was first made, so hint and undo penalties are not
+// part of the replayed score. A move group that can't be made stops the
+// replay and is reported rather than returned as an error. Only the last
+// position is searched in full to tell whether it is stuck: the player could
+// not have moved on from an earlier one that was.
+func (r Replay) CallReplay(ctx context.Context, game poc.Replay) (poc.Replay, error) {
+	saved := game.SavedGameDetail
+	rs, ok := ruleset(saved.Variant)
+	if !ok {
+		return game, poc.Error{Actual: ErrUnknownRuleset, Category: poc.SemanticError}
+	}
+	if game.MoveNumber < 0 || int(game.MoveNumber) > len(saved.History) {
+		return game, poc.Error{Actual: ErrNoSuchMove, Category: poc.NotFoundError}
+	}
+
+	var detail poc.SavedGameDetail
+	detail.Variant = saved.Variant
+	detail.Seed = saved.Seed
+	detail.Board = rs.Deal(saved.Variant, rs.Shuffle(saved.Variant, saved.Seed))
+	detail.Board.Score = rs.Start(saved.Variant)
+	detail.Status = status(detail, 1)
+
+	game.Boards = nil
+	game.Inconsistent = 0
+	game.Problem = ""
+	if game.Stream {
+		game.Boards = append(game.Boards, detail.Board)
+	}
+	steps := []poc.PerformMoveCaller{Validate{}, Apply{}, Score{}, Status{MaxPositions: 1}}
+	for i, next := range saved.History[:game.MoveNumber] {
+		move := poc.PerformMove{Next: next, SavedGameDetail: detail}
+		var err error
+		for _, step := range steps {
+			move, err = step.CallPerformMove(ctx, move)
+			if err != nil {
+				break
+			}
+		}
+		if err != nil {
+			game.Inconsistent = int32(i + 1)
+			game.Problem = err.Error()
+			break
+		}
+		detail = move.SavedGameDetail
+		if game.Stream {
+			game.Boards = append(game.Boards, detail.Board)
+		}
+	}
+	game.Board = detail.Board
+	game.Status = status(detail, stuckLimit)
+	return game, nil
+}
@@ -0,0 +1,123 @@
+package rules_test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/slcjordan/poc"
+	"github.com/slcjordan/poc/pipeline"
+	"github.com/slcjordan/poc/rules"
+	"github.com/slcjordan/poc/test/assert"
+	"github.com/slcjordan/poc/test/harness"
+	"github.com/slcjordan/poc/test/logger"
+)
+
+// played deals a game by seed and makes steps random moves, returning the
+// game and the board after each move.
+func played(t *testing.T, variant poc.Variant, steps int) (poc.SavedGameDetail, []poc.Board) {
+	ctx := context.Background()
+	random := rand.New(rand.NewSource(3))
+	start, err := pipeline.StartGame{rules.Shuffle{random}, rules.Score{}, rules.Status{}, rules.NextMove{}}.CallStartGame(ctx, poc.StartGame{Variant: variant, Seed: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	game := start.SavedGameDetail
+	boards := []poc.Board{game.Board}
+	for step := 0; step < steps && len(game.PossibleNextMoves) > 0; step++ {
+		next := game.PossibleNextMoves[random.Intn(len(game.PossibleNextMoves))]
+		move, err := pipeline.PerformMove{rules.Validate{}, rules.Apply{}, rules.Score{}, rules.Status{}, rules.NextMove{}}.CallPerformMove(ctx, poc.PerformMove{SavedGameDetail: game, Next: next})
+		if err != nil {
+			t.Fatal(err)
+		}
+		game = move.SavedGameDetail
+		boards = append(boards, game.Board)
+	}
+	return game, boards
+}
+
+func TestReplay(t *testing.T) {
+	logger.RegisterVerbose(t)
+	game, _ := played(t, poc.Variant{Scoring: poc.VegasScoring}, 8)
+	broken := game
+	broken.History = append([][]poc.Move(nil), game.History...)
+	broken.History[2] = []poc.Move{{OldPileNum: 12, NewPileNum: 2}}
+	unknown := game
+	unknown.Variant.Name = "canfield"
+
+	harness.Replay{
+		{
+			Desc:    "Every board on the way",
+			Command: rules.Replay{},
+			Input:   poc.Replay{MoveNumber: 8, Stream: true, SavedGameDetail: game},
+			Result: assert.New().NoError().
+				Replay.Inconsistent(assert.Equals(0)).
+				Replay.Status.Uint8(assert.Equals(poc.InProgress)).
+				Replay.Boards.Length(assert.Equals(9)).
+				Replay.Board.Score(assert.Equals(int64(game.Board.Score))),
+		},
+		{
+			Desc:    "The dealt board",
+			Command: rules.Replay{},
+			Input:   poc.Replay{SavedGameDetail: game},
+			Result: assert.New().NoError().
+				Replay.Boards.Length(assert.Equals(0)).
+				Replay.Board.Piles.Nth(8).Length(assert.Equals(7)).
+				Replay.Board.Score(assert.Equals(-52)),
+		},
+		{
+			Desc:    "Past the end of the history",
+			Command: rules.Replay{},
+			Input:   poc.Replay{MoveNumber: 9, SavedGameDetail: game},
+			Result:  assert.New().Error.Category.Uint8(assert.Equals(poc.NotFoundError)),
+		},
+		{
+			Desc:    "Unknown ruleset",
+			Command: rules.Replay{},
+			Input:   poc.Replay{MoveNumber: 1, SavedGameDetail: unknown},
+			Result:  assert.New().Error.Category.Uint8(assert.Equals(poc.SemanticError)),
+		},
+		{
+			Desc:    "Inconsistent history",
+			Command: rules.Replay{},
+			Input:   poc.Replay{MoveNumber: 8, Stream: true, SavedGameDetail: broken},
+			Result: assert.New().NoError().
+				Replay.Inconsistent(assert.Equals(3)).
+				Replay.Boards.Length(assert.Equals(3)),
+		},
+	}.Run(t)
+}
+
+// TestReplayRebuildsEveryBoard checks that every streamed board is the board
+// the game had after the same number of moves.
+func TestReplayRebuildsEveryBoard(t *testing.T) {
+	variants := []poc.Variant{
+		{},
+		{DrawCount: 3},
+		{Family: poc.Spider, Suits: 1},
+		{Family: poc.FreeCell},
+		{Family: poc.TriPeaks},
+	}
+	for _, variant := range variants {
+		game, boards := played(t, variant, 40)
+		replay, err := rules.Replay{}.CallReplay(context.Background(), poc.Replay{
+			MoveNumber:      int32(len(game.History)),
+			Stream:          true,
+			SavedGameDetail: game,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if replay.Inconsistent != 0 {
+			t.Fatalf("variant %+v: move %d was inconsistent: %s", variant, replay.Inconsistent, replay.Problem)
+		}
+		if len(replay.Boards) != len(boards) {
+			t.Fatalf("variant %+v: expected %d boards but got %d", variant, len(boards), len(replay.Boards))
+		}
+		for i, board := range replay.Boards {
+			if rules.Hash(board) != rules.Hash(boards[i]) || board.Score != boards[i].Score {
+				t.Fatalf("variant %+v: board %d was %v but expected %v", variant, i, board, boards[i])
+			}
+		}
+	}
+}
@@ -3,28 +3,70 @@ package rules
 import (
 	"context"
 	"errors"
-	"math/rand"
-	"sort"
+	"fmt"
+	"io"
 
 	"github.com/slcjordan/poc"
+	"github.com/slcjordan/poc/fairness"
 )
 
 // ErrInvalidMove means the user tried a bad move.
 var ErrInvalidMove = errors.New("invalid move")
 
-func nextMoves(
-	stock []poc.PositionedCard,
-	talon []poc.PositionedCard,
-	tableau [][]poc.PositionedCard,
-	foundation [][]poc.PositionedCard) [][]poc.Move {
+// ErrKlondikeOnly means a command only knows how to play Klondike.
+var ErrKlondikeOnly = errors.New("only klondike games are supported")
+
+// timesThroughDeck counts the passes made through the stock so far. The first
+// pass starts with the deal and every recycle of the talon starts another.
+func timesThroughDeck(history [][]poc.Move) int32 {
+	result := int32(1)
+outer:
+	for _, currMove := range history {
+		for _, currCard := range currMove {
+			if currCard.NewPileNum == 0 {
+				result++
+				continue outer
+			}
+		}
+	}
+	return result
+}
+
+// canRecycle reports whether the talon may be returned to the stock.
+func canRecycle(game poc.SavedGameDetail) bool {
+	max := game.Variant.MaxTimesThroughDeck
+	return max < 1 || timesThroughDeck(game.History) < max
+}
+
+// moveCards moves the cards of a pile from pileIndex up onto the top of
+// another pile.
+func moveCards(piles [][]poc.PositionedCard, pileNum int, pileIndex int, newPileNum int) []poc.Move {
+	pile := piles[pileNum]
+	var moves []poc.Move
+	for idx := pileIndex; idx < len(pile); idx++ {
+		moves = append(moves, poc.Move{
+			OldPileNum:      pileNum,
+			OldPileIndex:    idx,
+			OldPilePosition: pile[idx].Position,
+			NewPileNum:      newPileNum,
+			NewPileIndex:    len(piles[newPileNum]) + idx - pileIndex,
+			NewPilePosition: pile[idx].Position,
+		})
+	}
+	return moves
+}
+
+// klondikeMoves lists the moves of a Klondike game.
+func klondikeMoves(game poc.SavedGameDetail) [][]poc.Move {
+	stock := game.Board.Piles[0]
+	talon := game.Board.Piles[1]
+	tableau := game.Board.Piles[2:9]
+	foundation := game.Board.Piles[9:]
 
 	var result [][]poc.Move
-	var suits [4]poc.Index // top cards on the foundation
+	var suits [4]poc.Index // top cards on the foundation, which may be jokers
 	for i, pile := range foundation {
-		if len(pile) < 1 {
-			continue
-		}
-		suits[i] = pile[len(pile)-1].Card.Index
+		suits[i] = poc.Index(len(pile))
 	}
 
 	// indexes of top tableau cards as move destinations.
@@ -33,10 +75,67 @@ func nextMoves(
 	// (Aces start at index 0).
 	var red [13][]int
 	var black [13][]int
+	var empty []int // empty tableau piles
+
+	// destinations are the tableau piles that card may be placed on.
+	destinations := func(card poc.PositionedCard) []int {
+		var result []int
+		if isJoker(card.Card) { // see jokerMoves
+			return nil
+		}
+		index := int(card.Card.Index - poc.Ace + 1)
+		if index < len(red) { // Kings can't be placed on top of other piles
+			switch card.Card.Suit {
+			case poc.Hearts, poc.Diamonds:
+				result = append(result, black[index]...)
+			case poc.Spades, poc.Clubs:
+				result = append(result, red[index]...)
+			}
+		}
+		switch game.Variant.EmptyColumnFill {
+		case poc.KingsOnlyFill:
+			if card.Card.Index == poc.King {
+				result = append(result, empty...)
+			}
+		case poc.AnyCardFill:
+			result = append(result, empty...)
+		}
+		return result
+	}
+	// toFoundation moves the top card of a pile onto its foundation pile.
+	toFoundation := func(pileNum int, pile []poc.PositionedCard) {
+		card := pile[len(pile)-1]
+		if isJoker(card.Card) || suits[card.Card.Suit-1] != card.Card.Index-1 {
+			return
+		}
+		result = append(result, []poc.Move{{
+			OldPileNum:      pileNum,
+			OldPileIndex:    len(pile) - 1,
+			OldPilePosition: card.Position,
+			NewPileNum:      int(card.Card.Suit + 8),
+			NewPileIndex:    len(foundation[card.Card.Suit-1]),
+			NewPilePosition: card.Position,
+		}})
+	}
+	// toTableau moves the top card of a pile onto every tableau pile that accepts it.
+	toTableau := func(pileNum int, pile []poc.PositionedCard) {
+		card := pile[len(pile)-1]
+		for _, curr := range destinations(card) {
+			result = append(result, []poc.Move{{
+				OldPileNum:      pileNum,
+				OldPileIndex:    len(pile) - 1,
+				OldPilePosition: card.Position,
+				NewPileNum:      curr + 2,
+				NewPileIndex:    len(tableau[curr]),
+				NewPilePosition: card.Position | poc.FaceUp,
+			}})
+		}
+	}
 
 	for pileNum := range tableau {
 		length := len(tableau[pileNum])
 		if length < 1 {
+			empty = append(empty, pileNum)
 			continue
 		}
 		card := tableau[pileNum][length-1]
@@ -59,16 +158,7 @@ func nextMoves(
 		case poc.Spades, poc.Clubs:
 			black[index] = append(black[index], pileNum)
 		}
-		if suits[card.Card.Suit-1] == card.Card.Index-1 { // move top face-up card onto a foundation pile
-			result = append(result, []poc.Move{{
-				OldPileNum:      int(card.Card.Suit + 7),
-				OldPileIndex:    length - 1,
-				OldPilePosition: card.Position,
-				NewPileNum:      int(card.Card.Suit + 7),
-				NewPileIndex:    len(foundation[card.Card.Suit-1]),
-				NewPilePosition: card.Position,
-			}})
-		}
+		toFoundation(pileNum+2, tableau[pileNum]) // move top face-up card onto a foundation pile
 	}
 
 	for pileNum := range tableau {
@@ -76,20 +166,10 @@ func nextMoves(
 			if card.Position&poc.FaceUp == 0 {
 				continue
 			}
-			suit := card.Card.Suit
-			index := int(card.Card.Index - poc.Ace - 1)
-			if index < 1 { // Aces can't be placed on top of other piles
-				continue
-			}
-			var dest []int
-			switch suit {
-			case poc.Hearts, poc.Diamonds:
-				dest = black[index]
-			case poc.Spades, poc.Clubs:
-				dest = red[index]
-			}
-
-			for _, curr := range dest { // move part of tableau pile onto another tableau pile
+			for _, curr := range destinations(card) { // move part of tableau pile onto another tableau pile
+				if i == 0 && len(tableau[curr]) < 1 { // moving a whole pile into an empty one changes nothing
+					continue
+				}
 				var currMove []poc.Move
 				for idx := i; idx < len(tableau[pileNum]); idx++ {
 					currMove = append(currMove, poc.Move{
@@ -106,17 +186,39 @@ func nextMoves(
 		}
 	}
 
-	if len(stock) > 0 { // draw a card from the stock.
-		card := stock[len(stock)-1]
-		result = append(result, []poc.Move{{
-			OldPileNum:      0,
-			OldPileIndex:    len(stock) - 1,
-			OldPilePosition: card.Position,
-			NewPileNum:      1,
-			NewPileIndex:    len(talon),
-			NewPilePosition: card.Position | poc.FaceUp,
-		}})
-	} else { // return talon to the stock
+	if len(talon) > 0 { // play the top talon card
+		toTableau(1, talon)
+		toFoundation(1, talon)
+	}
+
+	for i, pile := range foundation {
+		if len(pile) > 0 { // take the top foundation card back onto the tableau
+			toTableau(i+9, pile)
+		}
+	}
+
+	if len(stock) > 0 { // draw cards from the stock.
+		drawCount := int(game.Variant.DrawCount)
+		if drawCount < 1 {
+			drawCount = 1
+		}
+		if drawCount > len(stock) {
+			drawCount = len(stock)
+		}
+		var currMove []poc.Move
+		for i := 0; i < drawCount; i++ {
+			card := stock[len(stock)-1-i]
+			currMove = append(currMove, poc.Move{
+				OldPileNum:      0,
+				OldPileIndex:    len(stock) - 1 - i,
+				OldPilePosition: card.Position,
+				NewPileNum:      1,
+				NewPileIndex:    len(talon) + i,
+				NewPilePosition: card.Position | poc.FaceUp,
+			})
+		}
+		result = append(result, currMove)
+	} else if len(talon) > 0 && canRecycle(game) { // return talon to the stock
 		length := len(talon)
 		var currMove []poc.Move
 
@@ -132,6 +234,9 @@ func nextMoves(
 		}
 		result = append(result, currMove)
 	}
+	if game.Variant.Jokers > 0 {
+		result = append(result, jokerMoves(game)...)
+	}
 	return result
 }
 
@@ -246,101 +351,225 @@ type Validate struct{}
 
 // CallPerformMove checks that the move can actually be performed.
 func (v Validate) CallPerformMove(ctx context.Context, move poc.PerformMove) (poc.PerformMove, error) {
-	// get list of possible moves
-	possible := nextMoves(
-		move.SavedGameDetail.Board.Piles[0],
-		move.SavedGameDetail.Board.Piles[1],
-		move.SavedGameDetail.Board.Piles[2:9],
-		move.SavedGameDetail.Board.Piles[9:],
-	)
-	// sort everything
-	for i := 0; i < len(possible); i++ {
-		curr := sortable(possible[i])
-		sort.Sort(&curr)
-	}
-	input := sortable(move.Next)
-	sort.Sort(&input)
-	sort.Slice(possible, func(i int, j int) bool {
-		a := sortable(possible[i])
-		b := sortable(possible[j])
-		return (&a).Compare(&b) <= 0
-	})
-	// perform binary search on possible moves to find the input in the list of valid moves.
-	result := sort.Search(len(possible), func(i int) bool {
-		a := sortable(possible[i])
-		return (&a).Compare(&input) >= 0
-	})
-	if result >= len(possible) {
-		return move, poc.Error{Actual: ErrInvalidMove, Category: poc.SemanticError}
+	if move.SavedGameDetail.Status != poc.InProgress {
+		return move, poc.Error{Actual: ErrGameOver, Category: poc.SemanticError}
+	}
+	r, ok := ruleset(move.SavedGameDetail.Variant)
+	if !ok {
+		return move, poc.Error{Actual: ErrUnknownRuleset, Category: poc.SemanticError}
 	}
-	target := sortable(possible[result])
-	if (&target).Compare(&input) != 0 {
+	if len(move.SavedGameDetail.Board.Piles) != r.Piles(move.SavedGameDetail.Variant) {
 		return move, poc.Error{Actual: ErrInvalidMove, Category: poc.SemanticError}
 	}
+	err := r.Validate(move.SavedGameDetail, move.Next)
+	if err != nil {
+		return move, poc.Error{Actual: err, Category: poc.SemanticError}
+	}
 	return move, nil
 }
 
-// NextMove hydrates next move in result with the next reachable moves.
-type NextMove struct{}
+// apply returns a copy of piles with the move group performed. Every card in
+// the group is lifted from its old position before any card is placed, so the
+// order of moves within a group does not matter.
+func apply(piles [][]poc.PositionedCard, moves []poc.Move) ([][]poc.PositionedCard, error) {
+	type location struct {
+		pileNum   int
+		pileIndex int
+	}
+	lifted := make(map[location]bool, len(moves))
+	touched := make(map[int]bool)
 
-// CallStartGame moves.
-func (n NextMove) CallStartGame(ctx context.Context, game poc.StartGame) (poc.StartGame, error) {
-	var timesThroughDeck int32
-outer:
-	for _, currMove := range game.SavedGameDetail.History {
-		for _, currCard := range currMove {
-			if currCard.NewPileNum == 0 {
-				timesThroughDeck++
-				continue outer
+	for _, m := range moves {
+		if m.OldPileNum < 0 || m.OldPileNum >= len(piles) ||
+			m.OldPileIndex < 0 || m.OldPileIndex >= len(piles[m.OldPileNum]) {
+			return nil, fmt.Errorf("%w: no card at pile %d index %d", ErrInvalidMove, m.OldPileNum, m.OldPileIndex)
+		}
+		if m.NewPileNum < 0 || m.NewPileNum >= len(piles) {
+			return nil, fmt.Errorf("%w: no pile %d", ErrInvalidMove, m.NewPileNum)
+		}
+		if piles[m.OldPileNum][m.OldPileIndex].Position != m.OldPilePosition {
+			return nil, fmt.Errorf("%w: card at pile %d index %d is not in position %d", ErrInvalidMove, m.OldPileNum, m.OldPileIndex, m.OldPilePosition)
+		}
+		from := location{m.OldPileNum, m.OldPileIndex}
+		if lifted[from] {
+			return nil, fmt.Errorf("%w: card at pile %d index %d moved twice", ErrInvalidMove, m.OldPileNum, m.OldPileIndex)
+		}
+		lifted[from] = true
+		touched[m.OldPileNum] = true
+		touched[m.NewPileNum] = true
+	}
+
+	result := make([][]poc.PositionedCard, len(piles))
+	copy(result, piles)
+
+	for pileNum := range touched {
+		var remaining []poc.PositionedCard
+		for pileIndex, card := range piles[pileNum] {
+			if !lifted[location{pileNum, pileIndex}] {
+				remaining = append(remaining, card)
+			}
+		}
+		var arrivals []poc.Move
+		for _, m := range moves {
+			if m.NewPileNum == pileNum {
+				arrivals = append(arrivals, m)
+			}
+		}
+		pile := make([]poc.PositionedCard, len(remaining)+len(arrivals))
+		filled := make([]bool, len(pile))
+		for _, m := range arrivals {
+			if m.NewPileIndex < 0 || m.NewPileIndex >= len(pile) || filled[m.NewPileIndex] {
+				return nil, fmt.Errorf("%w: cannot place card at pile %d index %d", ErrInvalidMove, m.NewPileNum, m.NewPileIndex)
+			}
+			card := piles[m.OldPileNum][m.OldPileIndex]
+			card.Position = m.NewPilePosition
+			pile[m.NewPileIndex] = card
+			filled[m.NewPileIndex] = true
+		}
+		next := 0
+		for i := range pile {
+			if filled[i] {
+				continue
 			}
+			pile[i] = remaining[next]
+			next++
 		}
+		result[pileNum] = pile
 	}
-	if timesThroughDeck >= game.Variant.MaxTimesThroughDeck && game.Variant.MaxTimesThroughDeck > 0 {
-		game.SavedGameDetail.PossibleNextMoves = nil
-		return game, nil
+	return result, nil
+}
+
+// Apply performs validated moves on the board.
+type Apply struct{}
+
+// CallPerformMove moves the cards described by move.Next between piles and
+// records the move group and the hash of the new position in the game
+// history. Undone moves can no longer be redone after a fresh move.
+func (a Apply) CallPerformMove(ctx context.Context, move poc.PerformMove) (poc.PerformMove, error) {
+	piles, err := apply(move.SavedGameDetail.Board.Piles, move.Next)
+	if err != nil {
+		return move, poc.Error{Actual: err, Category: poc.SemanticError}
+	}
+	move.SavedGameDetail.Hashes = nextHashes(move.SavedGameDetail, piles, move.Next)
+	move.SavedGameDetail.Board.Piles = piles
+
+	history := make([][]poc.Move, len(move.SavedGameDetail.History), len(move.SavedGameDetail.History)+1)
+	copy(history, move.SavedGameDetail.History)
+	move.SavedGameDetail.History = append(history, move.Next)
+	move.SavedGameDetail.Redo = nil
+	return move, nil
+}
+
+// possibleNextMoves lists the moves a player may make. Finished games have
+// none.
+func possibleNextMoves(game poc.SavedGameDetail) [][]poc.Move {
+	if game.Status != poc.InProgress {
+		return nil
 	}
-	game.SavedGameDetail.PossibleNextMoves = nextMoves(
-		game.SavedGameDetail.Board.Piles[0],
-		game.SavedGameDetail.Board.Piles[1],
-		game.SavedGameDetail.Board.Piles[2:9],
-		game.SavedGameDetail.Board.Piles[9:],
-	)
+	return nextMoves(game)
+}
+
+// NextMove hydrates next move in result with the next reachable moves and
+// flags games that are going round in circles.
+type NextMove struct{}
+
+// CallStartGame moves.
+func (n NextMove) CallStartGame(ctx context.Context, game poc.StartGame) (poc.StartGame, error) {
+	game.SavedGameDetail.PossibleNextMoves = possibleNextMoves(game.SavedGameDetail)
+	game.SavedGameDetail.Looping = looping(game.SavedGameDetail)
 	return game, nil
 }
 
 // CallPerformMove moves.
 func (n NextMove) CallPerformMove(ctx context.Context, move poc.PerformMove) (poc.PerformMove, error) {
-	move.SavedGameDetail.PossibleNextMoves = nextMoves(
-		move.SavedGameDetail.Board.Piles[0],
-		move.SavedGameDetail.Board.Piles[1],
-		move.SavedGameDetail.Board.Piles[2:9],
-		move.SavedGameDetail.Board.Piles[9:],
-	)
+	move.SavedGameDetail.PossibleNextMoves = possibleNextMoves(move.SavedGameDetail)
+	move.SavedGameDetail.Looping = looping(move.SavedGameDetail)
 	return move, nil
 }
 
-// Shuffle shuffles the deck.
-type Shuffle struct{ Source rand.Source }
+// CallLookupGame moves.
+func (n NextMove) CallLookupGame(ctx context.Context, game poc.LookupGame) (poc.LookupGame, error) {
+	game.SavedGameDetail.PossibleNextMoves = possibleNextMoves(game.SavedGameDetail)
+	game.SavedGameDetail.Looping = looping(game.SavedGameDetail)
+	return game, nil
+}
+
+// CallAutoComplete clears the moves of the finished game.
+func (n NextMove) CallAutoComplete(ctx context.Context, game poc.AutoComplete) (poc.AutoComplete, error) {
+	game.SavedGameDetail.PossibleNextMoves = possibleNextMoves(game.SavedGameDetail)
+	game.SavedGameDetail.Looping = looping(game.SavedGameDetail)
+	return game, nil
+}
+
+// CallResignGame clears the moves of the resigned game.
+func (n NextMove) CallResignGame(ctx context.Context, game poc.ResignGame) (poc.ResignGame, error) {
+	game.SavedGameDetail.PossibleNextMoves = possibleNextMoves(game.SavedGameDetail)
+	game.SavedGameDetail.Looping = looping(game.SavedGameDetail)
+	return game, nil
+}
+
+// CallUndoMove moves.
+func (n NextMove) CallUndoMove(ctx context.Context, game poc.UndoMove) (poc.UndoMove, error) {
+	game.SavedGameDetail.PossibleNextMoves = possibleNextMoves(game.SavedGameDetail)
+	game.SavedGameDetail.Looping = looping(game.SavedGameDetail)
+	return game, nil
+}
+
+// CallRedoMove moves.
+func (n NextMove) CallRedoMove(ctx context.Context, game poc.RedoMove) (poc.RedoMove, error) {
+	game.SavedGameDetail.PossibleNextMoves = possibleNextMoves(game.SavedGameDetail)
+	game.SavedGameDetail.Looping = looping(game.SavedGameDetail)
+	return game, nil
+}
+
+// Shuffle shuffles the deck. Source is read for the salt of every game and the
+// seed of games started without one, so it should be crypto/rand.Reader.
+type Shuffle struct{ Source io.Reader }
 
-// CallStartGame shuffles a new deck and deals it to the result.Board piles.
+// CallStartGame shuffles a new deck and deals it to the result.Board piles
+// using the requested variant. The same seed always deals the same game. The
+// deck order is committed to before it is dealt.
 func (s Shuffle) CallStartGame(ctx context.Context, game poc.StartGame) (poc.StartGame, error) {
-	cards := make([]poc.PositionedCard, 52)
-
-	for i := range cards {
-		cards[i].Card.Suit = poc.Suit((i % 4) + 1)
-		cards[i].Card.Index = poc.Index((i / 4) + 1)
-	}
-	swap := func(i int, j int) { cards[i], cards[j] = cards[j], cards[i] }
-	rand.New(s.Source).Shuffle(len(cards), swap)
-
-	game.SavedGameDetail.Board.Piles[8] = cards[21:28]
-	game.SavedGameDetail.Board.Piles[7] = cards[15:21]
-	game.SavedGameDetail.Board.Piles[6] = cards[10:15]
-	game.SavedGameDetail.Board.Piles[5] = cards[6:10]
-	game.SavedGameDetail.Board.Piles[4] = cards[3:6]
-	game.SavedGameDetail.Board.Piles[3] = cards[1:3]
-	game.SavedGameDetail.Board.Piles[2] = cards[:1]
-	game.SavedGameDetail.Board.Piles[0] = cards[28:]
+	game.SavedGameDetail.Variant = game.Variant
+	game.SavedGameDetail.Variant.Name = rulesetName(game.Variant)
+	r, ok := ruleset(game.SavedGameDetail.Variant)
+	if !ok {
+		return game, poc.Error{Actual: ErrUnknownRuleset, Category: poc.MalformedError}
+	}
+	game.SavedGameDetail.Seed = game.Seed
+	if game.SavedGameDetail.Seed == 0 {
+		seed, err := newSeed(s.Source)
+		if err != nil {
+			return game, poc.Error{Actual: err, Category: poc.UnavailableError}
+		}
+		game.SavedGameDetail.Seed = r.Seed(seed)
+	}
+	err := jokers(game.Variant)
+	if err != nil {
+		return game, poc.Error{Actual: err, Category: poc.MalformedError}
+	}
+	variant, err := r.Setup(game.SavedGameDetail.Variant, game.SavedGameDetail.Seed)
+	if err != nil {
+		return game, poc.Error{Actual: err, Category: poc.MalformedError}
+	}
+	game.SavedGameDetail.Variant = variant
+	cards := r.Shuffle(variant, game.SavedGameDetail.Seed)
+
+	salt, err := fairness.NewSalt(s.Source)
+	if err != nil {
+		return game, poc.Error{Actual: err, Category: poc.UnavailableError}
+	}
+	commitment, err := fairness.Commit(salt, deckOrder(cards))
+	if err != nil {
+		return game, poc.Error{Actual: err, Category: poc.UnknownError}
+	}
+	game.SavedGameDetail.Salt = salt
+	game.SavedGameDetail.Commitment = commitment
+
+	board := r.Deal(variant, cards)
+	game.SavedGameDetail.Board.Piles = board.Piles
+	game.SavedGameDetail.Board.Slots = board.Slots
+	game.SavedGameDetail.Board.Covers = board.Covers
 	return game, nil
 }
 
@@ -12,8 +12,24 @@ import (
 	"github.com/slcjordan/poc/test/logger"
 )
 
+// klondike returns an empty Klondike board.
+func klondike() poc.Board {
+	return poc.Board{Piles: make([][]poc.PositionedCard, 13)}
+}
+
+// clone copies a board so that its piles can be changed without changing the
+// original.
+func clone(board poc.Board) poc.Board {
+	board.Piles = append([][]poc.PositionedCard(nil), board.Piles...)
+	return board
+}
+
 func TestNextMoves(t *testing.T) {
 	logger.RegisterVerbose(t)
+	talonOnly := klondike()
+	talonOnly.Piles[1] = []poc.PositionedCard{
+		{Position: poc.FaceUp, Card: poc.Card{Suit: poc.Hearts, Index: poc.Two}},
+	}
 	harness.StartGame{
 		{
 			Desc:    "Sanity check",
@@ -23,22 +39,17 @@ func TestNextMoves(t *testing.T) {
 		{
 			Desc: "Given 0 random seed",
 			Command: pipeline.StartGame{
-				rules.Shuffle{rand.NewSource(0)},
+				rules.Shuffle{rand.New(rand.NewSource(0))},
 				rules.NextMove{},
 			},
 			Result: assert.New().StartGame.SavedGameDetail.PossibleNextMoves.Length(assert.Equals(8)),
 		},
 		{
-			Desc: "Max times variant is respected",
-			Command: pipeline.StartGame{
-				rules.Shuffle{rand.NewSource(0)},
-				rules.NextMove{},
-			},
+			Desc:    "Max times variant is respected",
+			Command: rules.NextMove{},
 			Input: poc.StartGame{
-				Variant: poc.Variant{
-					MaxTimesThroughDeck: 1,
-				},
 				SavedGameDetail: poc.SavedGameDetail{
+					Board: talonOnly,
 					History: [][]poc.Move{
 						{
 							{
@@ -46,10 +57,365 @@ func TestNextMoves(t *testing.T) {
 							},
 						},
 					},
+					Variant: poc.Variant{
+						MaxTimesThroughDeck: 2,
+					},
 				},
 			},
 			Result: assert.New().NoError().
 				StartGame.SavedGameDetail.PossibleNextMoves.Length(assert.Equals(0)),
 		},
+		{
+			Desc:    "Talon can be recycled until the max times through the deck",
+			Command: rules.NextMove{},
+			Input: poc.StartGame{
+				SavedGameDetail: poc.SavedGameDetail{
+					Board: talonOnly,
+					Variant: poc.Variant{
+						MaxTimesThroughDeck: 2,
+					},
+				},
+			},
+			Result: assert.New().NoError().
+				StartGame.SavedGameDetail.PossibleNextMoves.Length(assert.Equals(1)),
+		},
+	}.Run(t)
+}
+
+func TestShuffle(t *testing.T) {
+	logger.RegisterVerbose(t)
+	harness.StartGame{
+		{
+			Desc:    "A seed always deals the same game",
+			Command: rules.Shuffle{rand.New(rand.NewSource(0))},
+			Input:   poc.StartGame{Seed: 12345},
+			Result: func() *assert.Assertion {
+				a := assert.New().NoError()
+				a.StartGame.SavedGameDetail.Seed(assert.Equals(12345))
+				a.StartGame.SavedGameDetail.Board.Piles.Nth(2).Nth(0).Card.Suit.Uint8(assert.Equals(poc.Spades))
+				a.StartGame.SavedGameDetail.Board.Piles.Nth(2).Nth(0).Card.Index.Uint8(assert.Equals(poc.Eight))
+				a.StartGame.SavedGameDetail.Board.Piles.Nth(0).Nth(23).Card.Suit.Uint8(assert.Equals(poc.Hearts))
+				return a.StartGame.SavedGameDetail.Board.Piles.Nth(0).Nth(23).Card.Index.Uint8(assert.Equals(poc.Jack))
+			}(),
+		},
+		{
+			Desc: "A seeded game is dealt even if it might not be winnable",
+			Command: rules.WinnableDeal{
+				Shuffle:  rules.Shuffle{rand.New(rand.NewSource(0))},
+				Solve:    rules.Solve{MaxNodes: 1},
+				MaxDeals: 1,
+			},
+			Input:  poc.StartGame{Seed: 12345, WinnableOnly: true},
+			Result: assert.New().NoError().StartGame.SavedGameDetail.Seed(assert.Equals(12345)),
+		},
+	}.Run(t)
+}
+
+func TestDrawCount(t *testing.T) {
+	logger.RegisterVerbose(t)
+	board := klondike()
+	board.Piles[0] = []poc.PositionedCard{
+		{Card: poc.Card{Suit: poc.Spades, Index: poc.Nine}},
+		{Card: poc.Card{Suit: poc.Clubs, Index: poc.Nine}},
+		{Card: poc.Card{Suit: poc.Spades, Index: poc.Two}},
+		{Card: poc.Card{Suit: poc.Hearts, Index: poc.Ace}},
+	}
+	drawThree := []poc.Move{
+		{OldPileNum: 0, OldPileIndex: 3, NewPileNum: 1, NewPileIndex: 0, NewPilePosition: poc.FaceUp},
+		{OldPileNum: 0, OldPileIndex: 2, NewPileNum: 1, NewPileIndex: 1, NewPilePosition: poc.FaceUp},
+		{OldPileNum: 0, OldPileIndex: 1, NewPileNum: 1, NewPileIndex: 2, NewPilePosition: poc.FaceUp},
+	}
+	harness.StartGame{
+		{
+			Desc:    "Draw three turns over three cards",
+			Command: rules.NextMove{},
+			Input: poc.StartGame{
+				SavedGameDetail: poc.SavedGameDetail{
+					Board:   board,
+					Variant: poc.Variant{DrawCount: 3},
+				},
+			},
+			Result: func() *assert.Assertion {
+				a := assert.New().NoError()
+				a.StartGame.SavedGameDetail.PossibleNextMoves.Length(assert.Equals(1))
+				a.StartGame.SavedGameDetail.PossibleNextMoves.Nth(0).Length(assert.Equals(3))
+				return a
+			}(),
+		},
+		{
+			Desc:    "Draw five turns over what is left of the stock",
+			Command: rules.NextMove{},
+			Input: poc.StartGame{
+				SavedGameDetail: poc.SavedGameDetail{
+					Board:   board,
+					Variant: poc.Variant{DrawCount: 5},
+				},
+			},
+			Result: assert.New().NoError().
+				StartGame.SavedGameDetail.PossibleNextMoves.Nth(0).Length(assert.Equals(4)),
+		},
+	}.Run(t)
+	harness.PerformMove{
+		{
+			Desc:    "Only the top talon card is playable",
+			Command: pipeline.PerformMove{rules.Validate{}, rules.Apply{}, rules.NextMove{}},
+			Input: poc.PerformMove{
+				Next: drawThree,
+				SavedGameDetail: poc.SavedGameDetail{
+					Board:   board,
+					Variant: poc.Variant{DrawCount: 3},
+				},
+			},
+			Result: func() *assert.Assertion {
+				a := assert.New().NoError()
+				a.PerformMove.SavedGameDetail.Board.Piles.Nth(1).Length(assert.Equals(3))
+				a.PerformMove.SavedGameDetail.Board.Piles.Nth(0).Length(assert.Equals(1))
+				// the nine of clubs has nowhere to go, so only the last card can be drawn.
+				a.PerformMove.SavedGameDetail.PossibleNextMoves.Length(assert.Equals(1))
+				return a
+			}(),
+		},
+	}.Run(t)
+}
+
+func TestApply(t *testing.T) {
+	logger.RegisterVerbose(t)
+	board := klondike()
+	board.Piles[0] = []poc.PositionedCard{
+		{Card: poc.Card{Suit: poc.Spades, Index: poc.Two}},
+		{Card: poc.Card{Suit: poc.Hearts, Index: poc.Ace}},
+	}
+	board.Piles[2] = []poc.PositionedCard{
+		{Card: poc.Card{Suit: poc.Clubs, Index: poc.Nine}},
+		{Position: poc.FaceUp, Card: poc.Card{Suit: poc.Hearts, Index: poc.Five}},
+		{Position: poc.FaceUp, Card: poc.Card{Suit: poc.Spades, Index: poc.Four}},
+	}
+	board.Piles[3] = []poc.PositionedCard{
+		{Position: poc.FaceUp, Card: poc.Card{Suit: poc.Clubs, Index: poc.Six}},
+	}
+	harness.PerformMove{
+		{
+			Desc:    "Draw from the stock",
+			Command: rules.Apply{},
+			Input: poc.PerformMove{
+				Next: []poc.Move{
+					{OldPileNum: 0, OldPileIndex: 1, NewPileNum: 1, NewPileIndex: 0, NewPilePosition: poc.FaceUp},
+				},
+				SavedGameDetail: poc.SavedGameDetail{Board: board},
+			},
+			Result: func() *assert.Assertion {
+				a := assert.New().NoError()
+				a.PerformMove.SavedGameDetail.History.Length(assert.Equals(1))
+				a.PerformMove.SavedGameDetail.Board.Piles.Nth(0).Length(assert.Equals(1))
+				a.PerformMove.SavedGameDetail.Board.Piles.Nth(1).Length(assert.Equals(1))
+				a.PerformMove.SavedGameDetail.Board.Piles.Nth(1).Nth(0).Card.Index.Uint8(assert.Equals(poc.Ace))
+				return a.PerformMove.SavedGameDetail.Board.Piles.Nth(1).Nth(0).Position.Uint64(assert.Equals(poc.FaceUp))
+			}(),
+		},
+		{
+			Desc:    "Move a run between tableau piles",
+			Command: rules.Apply{},
+			Input: poc.PerformMove{
+				Next: []poc.Move{
+					{OldPileNum: 2, OldPileIndex: 2, OldPilePosition: poc.FaceUp, NewPileNum: 3, NewPileIndex: 2, NewPilePosition: poc.FaceUp},
+					{OldPileNum: 2, OldPileIndex: 1, OldPilePosition: poc.FaceUp, NewPileNum: 3, NewPileIndex: 1, NewPilePosition: poc.FaceUp},
+				},
+				SavedGameDetail: poc.SavedGameDetail{Board: board},
+			},
+			Result: func() *assert.Assertion {
+				a := assert.New().NoError()
+				a.PerformMove.SavedGameDetail.Board.Piles.Nth(2).Length(assert.Equals(1))
+				a.PerformMove.SavedGameDetail.Board.Piles.Nth(3).Length(assert.Equals(3))
+				a.PerformMove.SavedGameDetail.Board.Piles.Nth(3).Nth(1).Card.Index.Uint8(assert.Equals(poc.Five))
+				return a.PerformMove.SavedGameDetail.Board.Piles.Nth(3).Nth(2).Card.Index.Uint8(assert.Equals(poc.Four))
+			}(),
+		},
+		{
+			Desc:    "Flip a tableau card in place",
+			Command: rules.Apply{},
+			Input: poc.PerformMove{
+				Next: []poc.Move{
+					{OldPileNum: 2, OldPileIndex: 0, NewPileNum: 2, NewPileIndex: 0, NewPilePosition: poc.FaceUp},
+				},
+				SavedGameDetail: poc.SavedGameDetail{Board: board},
+			},
+			Result: func() *assert.Assertion {
+				a := assert.New().NoError()
+				a.PerformMove.SavedGameDetail.Board.Piles.Nth(2).Length(assert.Equals(3))
+				return a.PerformMove.SavedGameDetail.Board.Piles.Nth(2).Nth(0).Position.Uint64(assert.Equals(poc.FaceUp))
+			}(),
+		},
+		{
+			Desc:    "Missing card is rejected",
+			Command: rules.Apply{},
+			Input: poc.PerformMove{
+				Next: []poc.Move{
+					{OldPileNum: 4, OldPileIndex: 0, NewPileNum: 5, NewPileIndex: 0},
+				},
+				SavedGameDetail: poc.SavedGameDetail{Board: board},
+			},
+			Result: assert.New().Error.Category.Uint8(assert.Equals(poc.SemanticError)),
+		},
+		{
+			Desc:    "Gaps in the destination pile are rejected",
+			Command: rules.Apply{},
+			Input: poc.PerformMove{
+				Next: []poc.Move{
+					{OldPileNum: 2, OldPileIndex: 2, OldPilePosition: poc.FaceUp, NewPileNum: 3, NewPileIndex: 5, NewPilePosition: poc.FaceUp},
+				},
+				SavedGameDetail: poc.SavedGameDetail{Board: board},
+			},
+			Result: assert.New().Error.Category.Uint8(assert.Equals(poc.SemanticError)),
+		},
+	}.Run(t)
+}
+
+func TestValidate(t *testing.T) {
+	logger.RegisterVerbose(t)
+	faceUp := func(suit poc.Suit, index poc.Index) poc.PositionedCard {
+		return poc.PositionedCard{Position: poc.FaceUp, Card: poc.Card{Suit: suit, Index: index}}
+	}
+	board := klondike()
+	board.Piles[1] = []poc.PositionedCard{faceUp(poc.Clubs, poc.Two), faceUp(poc.Hearts, poc.Five)}
+	board.Piles[2] = []poc.PositionedCard{faceUp(poc.Clubs, poc.Six)}
+	board.Piles[3] = []poc.PositionedCard{faceUp(poc.Spades, poc.Five)}
+	board.Piles[9] = []poc.PositionedCard{
+		faceUp(poc.Hearts, poc.Ace),
+		faceUp(poc.Hearts, poc.Two),
+		faceUp(poc.Hearts, poc.Three),
+		faceUp(poc.Hearts, poc.Four),
+	}
+	game := poc.SavedGameDetail{Board: board}
+
+	harness.PerformMove{
+		{
+			Desc:    "Talon to tableau",
+			Command: rules.Validate{},
+			Input: poc.PerformMove{
+				Next: []poc.Move{
+					{OldPileNum: 1, OldPileIndex: 1, OldPilePosition: poc.FaceUp, NewPileNum: 2, NewPileIndex: 1, NewPilePosition: poc.FaceUp},
+				},
+				SavedGameDetail: game,
+			},
+			Result: assert.New().NoError(),
+		},
+		{
+			Desc:    "Talon to foundation",
+			Command: rules.Validate{},
+			Input: poc.PerformMove{
+				Next: []poc.Move{
+					{OldPileNum: 1, OldPileIndex: 1, OldPilePosition: poc.FaceUp, NewPileNum: 9, NewPileIndex: 4, NewPilePosition: poc.FaceUp},
+				},
+				SavedGameDetail: game,
+			},
+			Result: assert.New().NoError(),
+		},
+		{
+			Desc:    "Foundation to tableau",
+			Command: rules.Validate{},
+			Input: poc.PerformMove{
+				Next: []poc.Move{
+					{OldPileNum: 9, OldPileIndex: 3, OldPilePosition: poc.FaceUp, NewPileNum: 3, NewPileIndex: 1, NewPilePosition: poc.FaceUp},
+				},
+				SavedGameDetail: game,
+			},
+			Result: assert.New().NoError(),
+		},
+		{
+			Desc:    "Talon card onto the same color",
+			Command: rules.Validate{},
+			Input: poc.PerformMove{
+				Next: []poc.Move{
+					{OldPileNum: 1, OldPileIndex: 1, OldPilePosition: poc.FaceUp, NewPileNum: 3, NewPileIndex: 1, NewPilePosition: poc.FaceUp},
+				},
+				SavedGameDetail: game,
+			},
+			Result: assert.New().Error.Category.Uint8(assert.Equals(poc.SemanticError)),
+		},
+		{
+			Desc:    "Buried talon card",
+			Command: rules.Validate{},
+			Input: poc.PerformMove{
+				Next: []poc.Move{
+					{OldPileNum: 1, OldPileIndex: 0, OldPilePosition: poc.FaceUp, NewPileNum: 9, NewPileIndex: 4, NewPilePosition: poc.FaceUp},
+				},
+				SavedGameDetail: game,
+			},
+			Result: assert.New().Error.Category.Uint8(assert.Equals(poc.SemanticError)),
+		},
+	}.Run(t)
+
+	harness.StartGame{
+		{
+			Desc:    "Empty stock and talon offer no recycle move",
+			Command: rules.NextMove{},
+			Result: assert.New().NoError().
+				StartGame.SavedGameDetail.PossibleNextMoves.Length(assert.Equals(0)),
+		},
+		{
+			Desc:    "Talon, foundation and tableau moves are offered",
+			Command: rules.NextMove{},
+			Input:   poc.StartGame{SavedGameDetail: game},
+			Result: assert.New().NoError().
+				StartGame.SavedGameDetail.PossibleNextMoves.Length(assert.Equals(4)),
+		},
+	}.Run(t)
+}
+
+func TestEmptyColumnFill(t *testing.T) {
+	logger.RegisterVerbose(t)
+	faceUp := func(suit poc.Suit, index poc.Index) poc.PositionedCard {
+		return poc.PositionedCard{Position: poc.FaceUp, Card: poc.Card{Suit: suit, Index: index}}
+	}
+	board := klondike()
+	board.Piles[1] = []poc.PositionedCard{faceUp(poc.Clubs, poc.King)}
+	board.Piles[2] = []poc.PositionedCard{{Card: poc.Card{Suit: poc.Hearts, Index: poc.Two}}, faceUp(poc.Diamonds, poc.Queen)}
+	for pileNum := 4; pileNum < 9; pileNum++ {
+		board.Piles[pileNum] = []poc.PositionedCard{faceUp(poc.Spades, poc.Ace)}
+	}
+	kingToEmpty := []poc.Move{
+		{OldPileNum: 1, OldPileIndex: 0, OldPilePosition: poc.FaceUp, NewPileNum: 3, NewPileIndex: 0, NewPilePosition: poc.FaceUp},
+	}
+	queenToEmpty := []poc.Move{
+		{OldPileNum: 2, OldPileIndex: 1, OldPilePosition: poc.FaceUp, NewPileNum: 3, NewPileIndex: 0, NewPilePosition: poc.FaceUp},
+	}
+	wholePile := []poc.Move{
+		{OldPileNum: 4, OldPileIndex: 0, OldPilePosition: poc.FaceUp, NewPileNum: 3, NewPileIndex: 0, NewPilePosition: poc.FaceUp},
+	}
+	withFill := func(fill poc.ColumnFill) poc.SavedGameDetail {
+		return poc.SavedGameDetail{Board: board, Variant: poc.Variant{EmptyColumnFill: fill}}
+	}
+
+	harness.PerformMove{
+		{
+			Desc:    "King into an empty column by default",
+			Command: rules.Validate{},
+			Input:   poc.PerformMove{Next: kingToEmpty, SavedGameDetail: withFill(poc.KingsOnlyFill)},
+			Result:  assert.New().NoError(),
+		},
+		{
+			Desc:    "Queen into an empty column by default",
+			Command: rules.Validate{},
+			Input:   poc.PerformMove{Next: queenToEmpty, SavedGameDetail: withFill(poc.KingsOnlyFill)},
+			Result:  assert.New().Error.Category.Uint8(assert.Equals(poc.SemanticError)),
+		},
+		{
+			Desc:    "Queen into an empty column when any card may fill it",
+			Command: rules.Validate{},
+			Input:   poc.PerformMove{Next: queenToEmpty, SavedGameDetail: withFill(poc.AnyCardFill)},
+			Result:  assert.New().NoError(),
+		},
+		{
+			Desc:    "King into an empty column when no card may fill it",
+			Command: rules.Validate{},
+			Input:   poc.PerformMove{Next: kingToEmpty, SavedGameDetail: withFill(poc.NoCardFill)},
+			Result:  assert.New().Error.Category.Uint8(assert.Equals(poc.SemanticError)),
+		},
+		{
+			Desc:    "A whole pile into an empty column changes nothing",
+			Command: rules.Validate{},
+			Input:   poc.PerformMove{Next: wholePile, SavedGameDetail: withFill(poc.AnyCardFill)},
+			Result:  assert.New().Error.Category.Uint8(assert.Equals(poc.SemanticError)),
+		},
 	}.Run(t)
 }
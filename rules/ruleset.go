@@ -0,0 +1,143 @@
+package rules
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/slcjordan/poc"
+)
+
+// ErrUnknownRuleset means a game asked for a ruleset that was never registered.
+var ErrUnknownRuleset = errors.New("unknown ruleset")
+
+// A Ruleset is a kind of solitaire game. The commands in this package look up
+// the ruleset of a game by Variant.Name, so adding a game only means
+// registering a new one.
+type Ruleset interface {
+	// Piles is the number of piles on the board.
+	Piles(variant poc.Variant) int
+	// Seed maps a random seed to one the ruleset deals from.
+	Seed(random int64) int64
+	// Setup checks the variant and seed of a new game and fills in defaults.
+	Setup(variant poc.Variant, seed int64) (poc.Variant, error)
+	// Shuffle orders the deck dealt by seed.
+	Shuffle(variant poc.Variant, seed int64) []poc.PositionedCard
+	// Deal lays out a shuffled deck.
+	Deal(variant poc.Variant, cards []poc.PositionedCard) poc.Board
+	// Moves lists every move group allowed on the board.
+	Moves(game poc.SavedGameDetail) [][]poc.Move
+	// Validate checks that a move group is allowed on the board.
+	Validate(game poc.SavedGameDetail, moves []poc.Move) error
+	// Start is the score of a new game.
+	Start(variant poc.Variant) int32
+	// Points scores a move group.
+	Points(variant poc.Variant, moves []poc.Move) int32
+	// Won reports whether the game has been won.
+	Won(game poc.SavedGameDetail) bool
+	// Stuck reports whether there is nothing useful left to do, looking
+	// through at most limit positions.
+	Stuck(game poc.SavedGameDetail, limit int) bool
+}
+
+var rulesets = make(map[string]Ruleset)
+
+// Register should be called by a ruleset's init function. Registering a name
+// twice replaces the first ruleset.
+func Register(name string, r Ruleset) {
+	rulesets[name] = r
+}
+
+// rulesetName is the name of the ruleset a variant is played by. Variants
+// without a name are played by the ruleset named after their family.
+func rulesetName(variant poc.Variant) string {
+	if variant.Name != "" {
+		return variant.Name
+	}
+	return strings.ToLower(variant.Family.String())
+}
+
+// ruleset looks up the ruleset a variant is played by.
+func ruleset(variant poc.Variant) (Ruleset, bool) {
+	r, ok := rulesets[rulesetName(variant)]
+	return r, ok
+}
+
+// search looks for a move group in the list of possible move groups. The order
+// of moves within a group does not matter. Klondike checks move groups
+// directly instead, but Spider, FreeCell, Pyramid and TriPeaks still validate
+// by listing every possible move group and searching it.
+func search(possible [][]poc.Move, moves []poc.Move) error {
+	// sort everything
+	for i := 0; i < len(possible); i++ {
+		curr := sortable(possible[i])
+		sort.Sort(&curr)
+	}
+	input := sortable(append([]poc.Move(nil), moves...))
+	sort.Sort(&input)
+	sort.Slice(possible, func(i int, j int) bool {
+		a := sortable(possible[i])
+		b := sortable(possible[j])
+		return (&a).Compare(&b) <= 0
+	})
+	// perform binary search on possible moves to find the input in the list of valid moves.
+	result := sort.Search(len(possible), func(i int) bool {
+		a := sortable(possible[i])
+		return (&a).Compare(&input) >= 0
+	})
+	if result >= len(possible) {
+		return ErrInvalidMove
+	}
+	target := sortable(possible[result])
+	if (&target).Compare(&input) != 0 {
+		return ErrInvalidMove
+	}
+	return nil
+}
+
+// nextMoves lists every move group allowed by the game's ruleset. Boards with
+// the wrong number of piles have none.
+func nextMoves(game poc.SavedGameDetail) [][]poc.Move {
+	r, ok := ruleset(game.Variant)
+	if !ok || len(game.Board.Piles) != r.Piles(game.Variant) {
+		return nil
+	}
+	return r.Moves(game)
+}
+
+// won reports whether the game has been won by the rules of its ruleset.
+func won(game poc.SavedGameDetail) bool {
+	r, ok := ruleset(game.Variant)
+	if !ok || len(game.Board.Piles) != r.Piles(game.Variant) {
+		return false
+	}
+	return r.Won(game)
+}
+
+// stuck reports whether there is nothing useful left to do, looking through at
+// most limit positions.
+func stuck(game poc.SavedGameDetail, limit int) bool {
+	r, ok := ruleset(game.Variant)
+	if !ok {
+		return true
+	}
+	return r.Stuck(game, limit)
+}
+
+// points scores a move group by the rules of its ruleset.
+func points(variant poc.Variant, moves []poc.Move) int32 {
+	r, ok := ruleset(variant)
+	if !ok {
+		return 0
+	}
+	return r.Points(variant, moves)
+}
+
+// shuffle orders the deck of a game dealt by seed.
+func shuffle(variant poc.Variant, seed int64) []poc.PositionedCard {
+	r, ok := ruleset(variant)
+	if !ok {
+		return nil
+	}
+	return r.Shuffle(variant, seed)
+}
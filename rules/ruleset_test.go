@@ -0,0 +1,132 @@
+package rules_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/slcjordan/poc"
+	"github.com/slcjordan/poc/pipeline"
+	"github.com/slcjordan/poc/rules"
+	"github.com/slcjordan/poc/test/assert"
+	"github.com/slcjordan/poc/test/harness"
+	"github.com/slcjordan/poc/test/logger"
+)
+
+// solo is a game of one card: the Ace of Spades is dealt to pile 0 and won by
+// moving it onto pile 1.
+type solo struct{}
+
+func (s solo) Piles(variant poc.Variant) int                              { return 2 }
+func (s solo) Seed(random int64) int64                                    { return random }
+func (s solo) Setup(variant poc.Variant, seed int64) (poc.Variant, error) { return variant, nil }
+func (s solo) Start(variant poc.Variant) int32                            { return 7 }
+func (s solo) Points(variant poc.Variant, moves []poc.Move) int32         { return int32(len(moves)) }
+func (s solo) Stuck(game poc.SavedGameDetail, limit int) bool             { return false }
+
+func (s solo) Shuffle(variant poc.Variant, seed int64) []poc.PositionedCard {
+	return []poc.PositionedCard{{Position: poc.FaceUp, Card: poc.Card{Suit: poc.Spades, Index: poc.Ace}}}
+}
+
+func (s solo) Deal(variant poc.Variant, cards []poc.PositionedCard) poc.Board {
+	return poc.Board{Piles: [][]poc.PositionedCard{cards, nil}}
+}
+
+func (s solo) Moves(game poc.SavedGameDetail) [][]poc.Move {
+	if len(game.Board.Piles[0]) < 1 {
+		return nil
+	}
+	return [][]poc.Move{{{OldPileNum: 0, OldPilePosition: poc.FaceUp, NewPileNum: 1, NewPilePosition: poc.FaceUp}}}
+}
+
+func (s solo) Validate(game poc.SavedGameDetail, moves []poc.Move) error {
+	if len(s.Moves(game)) < 1 || len(moves) != 1 || moves[0].NewPileNum != 1 {
+		return rules.ErrInvalidMove
+	}
+	return nil
+}
+
+func (s solo) Won(game poc.SavedGameDetail) bool {
+	return len(game.Board.Piles[1]) > 0
+}
+
+func TestRuleset(t *testing.T) {
+	logger.RegisterVerbose(t)
+	rules.Register("solo", solo{})
+	variant := poc.Variant{Name: "solo"}
+	dealt := poc.Board{Piles: [][]poc.PositionedCard{
+		{{Position: poc.FaceUp, Card: poc.Card{Suit: poc.Spades, Index: poc.Ace}}},
+		nil,
+	}}
+	finished := poc.Board{Piles: [][]poc.PositionedCard{nil, dealt.Piles[0]}}
+
+	harness.StartGame{
+		{
+			Desc:    "Deal a registered ruleset",
+			Command: pipeline.StartGame{rules.Shuffle{rand.New(rand.NewSource(0))}, rules.Score{}},
+			Input:   poc.StartGame{Variant: variant},
+			Result: func() *assert.Assertion {
+				a := assert.New().NoError()
+				a.StartGame.SavedGameDetail.Board.Piles.Length(assert.Equals(2))
+				a.StartGame.SavedGameDetail.Board.Score(assert.Equals(7))
+				return a.StartGame.SavedGameDetail.Variant.Name(assert.EqualsString("solo"))
+			}(),
+		},
+		{
+			Desc:    "Games are named after their family by default",
+			Command: rules.Shuffle{rand.New(rand.NewSource(0))},
+			Input:   poc.StartGame{Variant: poc.Variant{Family: poc.Spider}},
+			Result: assert.New().NoError().
+				StartGame.SavedGameDetail.Variant.Name(assert.EqualsString("spider")),
+		},
+		{
+			Desc:    "Unknown rulesets can't be dealt",
+			Command: rules.Shuffle{rand.New(rand.NewSource(0))},
+			Input:   poc.StartGame{Variant: poc.Variant{Name: "canfield"}},
+			Result:  assert.New().Error.Category.Uint8(assert.Equals(poc.MalformedError)),
+		},
+		{
+			Desc:    "The ruleset decides when the game is won",
+			Command: rules.Status{},
+			Input:   poc.StartGame{SavedGameDetail: poc.SavedGameDetail{Board: finished, Variant: variant}},
+			Result: assert.New().NoError().
+				StartGame.SavedGameDetail.Status.Uint8(assert.Equals(poc.Won)),
+		},
+	}.Run(t)
+
+	harness.PerformMove{
+		{
+			Desc:    "Moves are checked by the stored ruleset",
+			Command: pipeline.PerformMove{rules.Validate{}, rules.Apply{}, rules.Score{}},
+			Input: poc.PerformMove{
+				Next: []poc.Move{
+					{OldPileNum: 0, OldPileIndex: 0, OldPilePosition: poc.FaceUp, NewPileNum: 1, NewPileIndex: 0, NewPilePosition: poc.FaceUp},
+				},
+				SavedGameDetail: poc.SavedGameDetail{Board: dealt, Variant: variant},
+			},
+			Result: func() *assert.Assertion {
+				a := assert.New().NoError()
+				a.PerformMove.SavedGameDetail.Board.Score(assert.Equals(1))
+				return a.PerformMove.SavedGameDetail.Board.Piles.Nth(1).Length(assert.Equals(1))
+			}(),
+		},
+		{
+			Desc:    "Moves are rejected by the stored ruleset",
+			Command: rules.Validate{},
+			Input: poc.PerformMove{
+				Next: []poc.Move{
+					{OldPileNum: 0, OldPileIndex: 0, OldPilePosition: poc.FaceUp, NewPileNum: 0, NewPileIndex: 0, NewPilePosition: poc.FaceUp},
+				},
+				SavedGameDetail: poc.SavedGameDetail{Board: dealt, Variant: variant},
+			},
+			Result: assert.New().Error.Category.Uint8(assert.Equals(poc.SemanticError)),
+		},
+		{
+			Desc:    "Games of unknown rulesets can't be played",
+			Command: rules.Validate{},
+			Input: poc.PerformMove{
+				SavedGameDetail: poc.SavedGameDetail{Board: dealt, Variant: poc.Variant{Name: "canfield"}},
+			},
+			Result: assert.New().Error.Category.Uint8(assert.Equals(poc.SemanticError)),
+		},
+	}.Run(t)
+}
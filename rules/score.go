@@ -0,0 +1,148 @@
+package rules
+
+import (
+	"context"
+	"errors"
+
+	"github.com/slcjordan/poc"
+)
+
+// ErrNoBalance means a game asked to carry on a balance it can not carry on.
+var ErrNoBalance = errors.New("only a won or resigned vegas game can carry its balance into a new vegas game")
+
+// Standard (Windows) points.
+const (
+	standardTalonToTableau      = 5
+	standardToFoundation        = 10
+	standardFlipTableau         = 5
+	standardFoundationToTableau = -15
+	standardRecycleTalon        = -100 // drawing one card at a time
+	standardRecycleTalonDraw3   = -20  // drawing three cards at a time
+)
+
+// Vegas points.
+const (
+	vegasDeal           = -52
+	vegasToFoundation   = 5
+	vegasFromFoundation = -5
+)
+
+func isTableau(pileNum int) bool {
+	return pileNum >= 2 && pileNum < 9
+}
+
+func isFoundation(pileNum int) bool {
+	return pileNum >= 9
+}
+
+// standardPoints scores a move group using Windows solitaire rules. Turning
+// the talon over costs less when cards are drawn three at a time.
+func standardPoints(variant poc.Variant, moves []poc.Move) int32 {
+	if len(moves) < 1 {
+		return 0
+	}
+	first := moves[0]
+	switch {
+	case first.OldPileNum == first.NewPileNum && first.OldPileIndex == first.NewPileIndex:
+		if first.OldPilePosition&poc.FaceUp == 0 && first.NewPilePosition&poc.FaceUp != 0 {
+			return standardFlipTableau
+		}
+	case first.OldPileNum == 1 && first.NewPileNum == 0:
+		if variant.DrawCount >= 3 {
+			return standardRecycleTalonDraw3
+		}
+		return standardRecycleTalon
+	case first.OldPileNum == 1 && isTableau(first.NewPileNum):
+		return standardTalonToTableau
+	case isFoundation(first.NewPileNum):
+		return standardToFoundation
+	case isFoundation(first.OldPileNum) && isTableau(first.NewPileNum):
+		return standardFoundationToTableau
+	}
+	return 0
+}
+
+// vegasPoints scores a move group using Vegas rules.
+func vegasPoints(moves []poc.Move) int32 {
+	var result int32
+	for _, m := range moves {
+		if isFoundation(m.NewPileNum) && !isFoundation(m.OldPileNum) {
+			result += vegasToFoundation
+		}
+		if isFoundation(m.OldPileNum) && !isFoundation(m.NewPileNum) {
+			result += vegasFromFoundation
+		}
+	}
+	return result
+}
+
+// Score keeps Board.Score according to Variant.Scoring.
+type Score struct{}
+
+// CallStartGame adds the starting score of the game's ruleset, such as the
+// charge for the deal in Vegas scoring. A Vegas game started after a won or
+// resigned Vegas game in game.Previous pays for its deal from that balance.
+func (s Score) CallStartGame(ctx context.Context, game poc.StartGame) (poc.StartGame, error) {
+	if game.SavedGameDetail.PreviousGameID != 0 {
+		previous := game.Previous
+		if previous.GameID != game.SavedGameDetail.PreviousGameID ||
+			previous.Variant.Scoring != poc.VegasScoring ||
+			game.SavedGameDetail.Variant.Scoring != poc.VegasScoring ||
+			(previous.Status != poc.Won && previous.Status != poc.Resigned) {
+			return game, poc.Error{Actual: ErrNoBalance, Category: poc.SemanticError}
+		}
+		game.SavedGameDetail.Board.Score = previous.Board.Score
+	}
+	if r, ok := ruleset(game.SavedGameDetail.Variant); ok {
+		game.SavedGameDetail.Board.Score += r.Start(game.SavedGameDetail.Variant)
+	}
+	return game, nil
+}
+
+// addPoints adds points to the score. Standard scores never drop below zero.
+func addPoints(game *poc.SavedGameDetail, points int32) {
+	game.Board.Score += points
+	if game.Variant.Scoring == poc.StandardScoring && game.Board.Score < 0 {
+		game.Board.Score = 0
+	}
+}
+
+// klondikePoints scores a Klondike move group according to variant.Scoring.
+func klondikePoints(variant poc.Variant, moves []poc.Move) int32 {
+	if variant.Scoring == poc.VegasScoring {
+		return vegasPoints(moves)
+	}
+	return standardPoints(variant, moves)
+}
+
+// CallPerformMove awards points for move.Next.
+func (s Score) CallPerformMove(ctx context.Context, move poc.PerformMove) (poc.PerformMove, error) {
+	addPoints(&move.SavedGameDetail, points(move.SavedGameDetail.Variant, move.Next))
+	return move, nil
+}
+
+// CallUndoMove takes back the points for the undone move group, which is on
+// top of the Redo stack, and charges Variant.UndoPenalty.
+func (s Score) CallUndoMove(ctx context.Context, game poc.UndoMove) (poc.UndoMove, error) {
+	redo := game.SavedGameDetail.Redo
+	if len(redo) > 0 {
+		addPoints(&game.SavedGameDetail, -points(game.SavedGameDetail.Variant, redo[len(redo)-1]))
+	}
+	addPoints(&game.SavedGameDetail, -game.SavedGameDetail.Variant.UndoPenalty)
+	return game, nil
+}
+
+// CallRedoMove awards points for the redone move group again.
+func (s Score) CallRedoMove(ctx context.Context, game poc.RedoMove) (poc.RedoMove, error) {
+	history := game.SavedGameDetail.History
+	if len(history) > 0 {
+		addPoints(&game.SavedGameDetail, points(game.SavedGameDetail.Variant, history[len(history)-1]))
+	}
+	return game, nil
+}
+
+// CallHint charges Variant.HintPenalty for the hint.
+func (s Score) CallHint(ctx context.Context, game poc.Hint) (poc.Hint, error) {
+	addPoints(&game.SavedGameDetail, -game.SavedGameDetail.Variant.HintPenalty)
+	return game, nil
+}
@@ -0,0 +1,177 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/slcjordan/poc"
+	"github.com/slcjordan/poc/rules"
+	"github.com/slcjordan/poc/test/assert"
+	"github.com/slcjordan/poc/test/harness"
+	"github.com/slcjordan/poc/test/logger"
+)
+
+func TestScore(t *testing.T) {
+	logger.RegisterVerbose(t)
+	harness.StartGame{
+		{
+			Desc:    "Standard deals are free",
+			Command: rules.Score{},
+			Result: assert.New().NoError().
+				StartGame.SavedGameDetail.Board.Score(assert.Equals(0)),
+		},
+		{
+			Desc:    "Vegas deals cost 52",
+			Command: rules.Score{},
+			Input: poc.StartGame{
+				SavedGameDetail: poc.SavedGameDetail{
+					Board:   poc.Board{Score: 100},
+					Variant: poc.Variant{Scoring: poc.VegasScoring},
+				},
+			},
+			Result: assert.New().NoError().
+				StartGame.SavedGameDetail.Board.Score(assert.Equals(48)),
+		},
+		{
+			Desc:    "Vegas deals are paid from the balance of the previous game",
+			Command: rules.Score{},
+			Input: poc.StartGame{
+				Previous: poc.SavedGameDetail{
+					GameID:  7,
+					Board:   poc.Board{Score: 30},
+					Variant: poc.Variant{Scoring: poc.VegasScoring},
+					Status:  poc.Won,
+				},
+				SavedGameDetail: poc.SavedGameDetail{
+					Variant:        poc.Variant{Scoring: poc.VegasScoring},
+					PreviousGameID: 7,
+				},
+			},
+			Result: assert.New().NoError().
+				StartGame.SavedGameDetail.Board.Score(assert.Equals(-22)),
+		},
+		{
+			Desc:    "a game in progress has no balance to carry on",
+			Command: rules.Score{},
+			Input: poc.StartGame{
+				Previous: poc.SavedGameDetail{
+					GameID:  7,
+					Board:   poc.Board{Score: 30},
+					Variant: poc.Variant{Scoring: poc.VegasScoring},
+					Status:  poc.InProgress,
+				},
+				SavedGameDetail: poc.SavedGameDetail{
+					Variant:        poc.Variant{Scoring: poc.VegasScoring},
+					PreviousGameID: 7,
+				},
+			},
+			Result: assert.New().Error.Category.Uint8(assert.Equals(poc.SemanticError)),
+		},
+		{
+			Desc:    "a standard game has no balance to carry on",
+			Command: rules.Score{},
+			Input: poc.StartGame{
+				Previous: poc.SavedGameDetail{
+					GameID: 7,
+					Board:  poc.Board{Score: 30},
+					Status: poc.Resigned,
+				},
+				SavedGameDetail: poc.SavedGameDetail{
+					Variant:        poc.Variant{Scoring: poc.VegasScoring},
+					PreviousGameID: 7,
+				},
+			},
+			Result: assert.New().Error.Category.Uint8(assert.Equals(poc.SemanticError)),
+		},
+	}.Run(t)
+	harness.PerformMove{
+		{
+			Desc:    "Standard talon to tableau",
+			Command: rules.Score{},
+			Input: poc.PerformMove{
+				Next: []poc.Move{{OldPileNum: 1, NewPileNum: 4}},
+			},
+			Result: assert.New().NoError().
+				PerformMove.SavedGameDetail.Board.Score(assert.Equals(5)),
+		},
+		{
+			Desc:    "Standard tableau to foundation",
+			Command: rules.Score{},
+			Input: poc.PerformMove{
+				Next: []poc.Move{{OldPileNum: 3, NewPileNum: 10}},
+			},
+			Result: assert.New().NoError().
+				PerformMove.SavedGameDetail.Board.Score(assert.Equals(10)),
+		},
+		{
+			Desc:    "Standard flip",
+			Command: rules.Score{},
+			Input: poc.PerformMove{
+				Next: []poc.Move{{OldPileNum: 3, NewPileNum: 3, NewPilePosition: poc.FaceUp}},
+			},
+			Result: assert.New().NoError().
+				PerformMove.SavedGameDetail.Board.Score(assert.Equals(5)),
+		},
+		{
+			Desc:    "Standard recycle never drops below zero",
+			Command: rules.Score{},
+			Input: poc.PerformMove{
+				Next: []poc.Move{
+					{OldPileNum: 1, OldPileIndex: 1, NewPileNum: 0, NewPileIndex: 0},
+					{OldPileNum: 1, OldPileIndex: 0, NewPileNum: 0, NewPileIndex: 1},
+				},
+				SavedGameDetail: poc.SavedGameDetail{Board: poc.Board{Score: 30}},
+			},
+			Result: assert.New().NoError().
+				PerformMove.SavedGameDetail.Board.Score(assert.Equals(0)),
+		},
+		{
+			Desc:    "Standard recycle costs 100 drawing one card",
+			Command: rules.Score{},
+			Input: poc.PerformMove{
+				Next:            []poc.Move{{OldPileNum: 1, NewPileNum: 0}},
+				SavedGameDetail: poc.SavedGameDetail{Board: poc.Board{Score: 150}},
+			},
+			Result: assert.New().NoError().
+				PerformMove.SavedGameDetail.Board.Score(assert.Equals(50)),
+		},
+		{
+			Desc:    "Standard recycle costs 20 drawing three cards",
+			Command: rules.Score{},
+			Input: poc.PerformMove{
+				Next: []poc.Move{{OldPileNum: 1, NewPileNum: 0}},
+				SavedGameDetail: poc.SavedGameDetail{
+					Board:   poc.Board{Score: 150},
+					Variant: poc.Variant{DrawCount: 3},
+				},
+			},
+			Result: assert.New().NoError().
+				PerformMove.SavedGameDetail.Board.Score(assert.Equals(130)),
+		},
+		{
+			Desc:    "Vegas foundation play",
+			Command: rules.Score{},
+			Input: poc.PerformMove{
+				Next: []poc.Move{{OldPileNum: 1, NewPileNum: 9}},
+				SavedGameDetail: poc.SavedGameDetail{
+					Board:   poc.Board{Score: -52},
+					Variant: poc.Variant{Scoring: poc.VegasScoring},
+				},
+			},
+			Result: assert.New().NoError().
+				PerformMove.SavedGameDetail.Board.Score(assert.Equals(-47)),
+		},
+		{
+			Desc:    "Vegas ignores tableau plays",
+			Command: rules.Score{},
+			Input: poc.PerformMove{
+				Next: []poc.Move{{OldPileNum: 1, NewPileNum: 4}},
+				SavedGameDetail: poc.SavedGameDetail{
+					Board:   poc.Board{Score: -52},
+					Variant: poc.Variant{Scoring: poc.VegasScoring},
+				},
+			},
+			Result: assert.New().NoError().
+				PerformMove.SavedGameDetail.Board.Score(assert.Equals(-52)),
+		},
+	}.Run(t)
+}
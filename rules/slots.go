@@ -0,0 +1,66 @@
+package rules
+
+import (
+	"github.com/slcjordan/poc"
+)
+
+// coverRows works out which slots cover which. A slot is covered by every
+// slot in the next row that overlaps it by half a card.
+func coverRows(slots []poc.Slot) []poc.Cover {
+	var result []poc.Cover
+	for _, under := range slots {
+		for _, over := range slots {
+			if over.Row != under.Row+1 {
+				continue
+			}
+			if over.Column == under.Column-1 || over.Column == under.Column+1 {
+				result = append(result, poc.Cover{PileNum: under.PileNum, CoveredBy: over.PileNum})
+			}
+		}
+	}
+	return result
+}
+
+// covered reports whether the cards of a pile are blocked by a pile on top of
+// it that still has cards.
+func covered(board poc.Board, pileNum int) bool {
+	for _, cover := range board.Covers {
+		if cover.PileNum == pileNum && len(board.Piles[cover.CoveredBy]) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// drawCard turns the top card of the stock (pile 0) over onto the talon
+// (pile 1).
+func drawCard(piles [][]poc.PositionedCard) []poc.Move {
+	stock, talon := piles[0], piles[1]
+	card := stock[len(stock)-1]
+	return []poc.Move{{
+		OldPileNum:      0,
+		OldPileIndex:    len(stock) - 1,
+		OldPilePosition: card.Position,
+		NewPileNum:      1,
+		NewPileIndex:    len(talon),
+		NewPilePosition: card.Position | poc.FaceUp,
+	}}
+}
+
+// recycleTalon turns the talon (pile 1) back over to make a new stock (pile 0).
+func recycleTalon(piles [][]poc.PositionedCard) []poc.Move {
+	talon := piles[1]
+	var result []poc.Move
+	for i := range talon {
+		card := talon[len(talon)-1-i]
+		result = append(result, poc.Move{
+			OldPileNum:      1,
+			OldPileIndex:    len(talon) - 1 - i,
+			OldPilePosition: card.Position,
+			NewPileNum:      0,
+			NewPileIndex:    i,
+			NewPilePosition: card.Position &^ poc.FaceUp,
+		})
+	}
+	return result
+}
@@ -0,0 +1,185 @@
+package rules
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/slcjordan/poc"
+)
+
+// ErrNoWinnableDeal means no winnable deal was found in time.
+var ErrNoWinnableDeal = errors.New("could not deal a winnable game")
+
+// movePriority ranks move groups so that the solver tries the moves most
+// likely to make progress first.
+func movePriority(moves []poc.Move) int {
+	first := moves[0]
+	switch {
+	case isFoundation(first.NewPileNum):
+		return 0
+	case first.OldPileNum == first.NewPileNum:
+		return 1
+	case isTableau(first.OldPileNum) && isTableau(first.NewPileNum):
+		return 2
+	case first.OldPileNum == 1 && isTableau(first.NewPileNum):
+		return 3
+	case isStockMove(moves):
+		return 4
+	}
+	return 5
+}
+
+// solverMoves lists the moves in priority order. None are left out, so a
+// search that runs to the end has seen every reachable position.
+func solverMoves(game poc.SavedGameDetail) [][]poc.Move {
+	result := nextMoves(game)
+	sort.SliceStable(result, func(i int, j int) bool {
+		return movePriority(result[i]) < movePriority(result[j])
+	})
+	return result
+}
+
+// solver is a depth first search that never visits a position twice.
+type solver struct {
+	ctx      context.Context
+	deadline time.Time // the zero time means no deadline
+	maxNodes int
+	nodes    int
+	limited  bool
+	seen     Transpositions // least times through the deck for each position
+	path     [][]poc.Move
+}
+
+// search reports whether game can be won and leaves the winning moves in
+// s.path. hash is the hash of the game's position.
+func (s *solver) search(game poc.SavedGameDetail, hash poc.Hash) (bool, error) {
+	if won(game) {
+		return true, nil
+	}
+	passes := timesThroughDeck(game.History)
+	prev, ok := s.seen[hash]
+	if ok && prev <= passes {
+		return false, nil
+	}
+	s.seen[hash] = passes
+	// the clock is read on every node rather than waiting for a timer, which
+	// may not get to run while the search keeps the processor busy.
+	if (s.maxNodes > 0 && s.nodes >= s.maxNodes) || (!s.deadline.IsZero() && time.Now().After(s.deadline)) {
+		s.limited = true
+		return false, nil
+	}
+	err := s.ctx.Err()
+	if err != nil {
+		return false, err
+	}
+	s.nodes++
+	for _, moves := range solverMoves(game) {
+		piles, err := apply(game.Board.Piles, moves)
+		if err != nil {
+			continue
+		}
+		next := game
+		next.Board.Piles = piles
+		// siblings share the history's backing array, which is fine since
+		// each one is finished before the next is searched.
+		next.History = append(game.History, moves)
+
+		s.path = append(s.path, moves)
+		ok, err := s.search(next, rehash(hash, game.Board.Piles, piles, moves))
+		if ok || err != nil || s.limited {
+			return ok, err
+		}
+		s.path = s.path[:len(s.path)-1]
+	}
+	return false, nil
+}
+
+// Solve searches for a winning sequence of moves. A MaxNodes or Timeout of 0
+// means no limit.
+type Solve struct {
+	MaxNodes int
+	Timeout  time.Duration
+}
+
+// CallSolveGame searches from the current board. Games are Unwinnable only if
+// every reachable position was searched; running out of nodes or time means
+// SearchLimitReached.
+func (s Solve) CallSolveGame(ctx context.Context, game poc.SolveGame) (poc.SolveGame, error) {
+	if game.SavedGameDetail.Variant.Family != poc.Klondike {
+		return game, poc.Error{Actual: ErrKlondikeOnly, Category: poc.UnimplementedError}
+	}
+	maxNodes := s.MaxNodes
+	if game.MaxNodes > 0 && (maxNodes < 1 || int(game.MaxNodes) < maxNodes) {
+		maxNodes = int(game.MaxNodes)
+	}
+	search := solver{
+		ctx:      ctx,
+		maxNodes: maxNodes,
+		seen:     make(Transpositions),
+	}
+	if s.Timeout > 0 {
+		search.deadline = time.Now().Add(s.Timeout)
+	}
+	start := game.SavedGameDetail
+	start.History = make([][]poc.Move, len(game.SavedGameDetail.History))
+	copy(start.History, game.SavedGameDetail.History)
+
+	ok, err := search.search(start, Hash(start.Board))
+	game.NodesSearched = int32(search.nodes)
+	game.Solution = nil
+	switch {
+	case ctx.Err() != nil:
+		return game, poc.Error{Actual: ctx.Err(), Category: poc.UnavailableError}
+	case err != nil, search.limited:
+		game.Solvability = poc.SearchLimitReached
+	case ok:
+		game.Solvability = poc.Winnable
+		game.Solution = search.path
+	default:
+		game.Solvability = poc.Unwinnable
+	}
+	return game, nil
+}
+
+// WinnableDeal deals until Solve finds a winnable game, but only when
+// StartGame.WinnableOnly is set. A game asked for by seed is always dealt as
+// is.
+type WinnableDeal struct {
+	Shuffle  Shuffle
+	Solve    Solve
+	MaxDeals int
+}
+
+// CallStartGame deals at most MaxDeals games. Solve.Timeout is shared by all
+// of the deals rather than given to each one.
+func (w WinnableDeal) CallStartGame(ctx context.Context, game poc.StartGame) (poc.StartGame, error) {
+	solve := w.Solve
+	var deadline time.Time
+	if solve.Timeout > 0 {
+		deadline = time.Now().Add(solve.Timeout)
+	}
+	for i := 0; ; i++ {
+		dealt, err := w.Shuffle.CallStartGame(ctx, game)
+		if err != nil || !game.WinnableOnly || game.Seed != 0 {
+			return dealt, err
+		}
+		if !deadline.IsZero() {
+			solve.Timeout = time.Until(deadline)
+			if solve.Timeout <= 0 {
+				return dealt, poc.Error{Actual: ErrNoWinnableDeal, Category: poc.UnavailableError}
+			}
+		}
+		solved, err := solve.CallSolveGame(ctx, poc.SolveGame{SavedGameDetail: dealt.SavedGameDetail})
+		if err != nil {
+			return dealt, err
+		}
+		if solved.Solvability == poc.Winnable {
+			return dealt, nil
+		}
+		if i+1 >= w.MaxDeals {
+			return dealt, poc.Error{Actual: ErrNoWinnableDeal, Category: poc.UnavailableError}
+		}
+	}
+}
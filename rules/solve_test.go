@@ -0,0 +1,162 @@
+package rules_test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/slcjordan/poc"
+	"github.com/slcjordan/poc/rules"
+	"github.com/slcjordan/poc/test/assert"
+	"github.com/slcjordan/poc/test/harness"
+	"github.com/slcjordan/poc/test/logger"
+)
+
+func TestSolve(t *testing.T) {
+	logger.RegisterVerbose(t)
+	nearlyWon := klondike() // the last two hearts are buried in the stock
+	for suit := poc.Hearts; suit <= poc.Spades; suit++ {
+		for index := poc.Ace; index <= poc.King; index++ {
+			if suit == poc.Hearts && index >= poc.Queen {
+				continue
+			}
+			nearlyWon.Piles[suit+8] = append(nearlyWon.Piles[suit+8], poc.PositionedCard{
+				Position: poc.FaceUp,
+				Card:     poc.Card{Suit: suit, Index: index},
+			})
+		}
+	}
+	nearlyWon.Piles[0] = []poc.PositionedCard{
+		{Card: poc.Card{Suit: poc.Hearts, Index: poc.King}},
+		{Card: poc.Card{Suit: poc.Hearts, Index: poc.Queen}},
+	}
+	blocked := klondike() // nothing in the stock can be played
+	blocked.Piles[0] = []poc.PositionedCard{
+		{Card: poc.Card{Suit: poc.Spades, Index: poc.Nine}},
+		{Card: poc.Card{Suit: poc.Clubs, Index: poc.Nine}},
+	}
+	blocked.Piles[1] = []poc.PositionedCard{
+		{Position: poc.FaceUp, Card: poc.Card{Suit: poc.Hearts, Index: poc.Two}},
+	}
+	harness.SolveGame{
+		{
+			Desc:    "Winnable",
+			Command: rules.Solve{},
+			Input: poc.SolveGame{
+				SavedGameDetail: poc.SavedGameDetail{Board: nearlyWon},
+			},
+			Result: func() *assert.Assertion {
+				a := assert.New().NoError()
+				a.SolveGame.Solvability.Uint8(assert.Equals(poc.Winnable))
+				a.SolveGame.Solution.Length(assert.Equals(4)) // draw, play, draw, play
+				return a
+			}(),
+		},
+		{
+			Desc:    "Unwinnable",
+			Command: rules.Solve{},
+			Input: poc.SolveGame{
+				SavedGameDetail: poc.SavedGameDetail{Board: blocked},
+			},
+			Result: func() *assert.Assertion {
+				a := assert.New().NoError()
+				a.SolveGame.Solvability.Uint8(assert.Equals(poc.Unwinnable))
+				a.SolveGame.Solution.Length(assert.Equals(0))
+				return a
+			}(),
+		},
+		{
+			Desc:    "Node limit",
+			Command: rules.Solve{MaxNodes: 100},
+			Input: poc.SolveGame{
+				MaxNodes:        1,
+				SavedGameDetail: poc.SavedGameDetail{Board: nearlyWon},
+			},
+			Result: func() *assert.Assertion {
+				a := assert.New().NoError()
+				a.SolveGame.Solvability.Uint8(assert.Equals(poc.SearchLimitReached))
+				a.SolveGame.NodesSearched(assert.Equals(1))
+				return a
+			}(),
+		},
+	}.Run(t)
+	harness.StartGame{
+		{
+			Desc: "Winnable deal",
+			Command: rules.WinnableDeal{
+				Shuffle:  rules.Shuffle{Source: rand.New(rand.NewSource(1))},
+				Solve:    rules.Solve{MaxNodes: 1000},
+				MaxDeals: 1,
+			},
+			Input:  poc.StartGame{WinnableOnly: true},
+			Result: assert.New().NoError(),
+		},
+		{
+			Desc: "No winnable deal",
+			Command: rules.WinnableDeal{
+				Shuffle:  rules.Shuffle{Source: rand.New(rand.NewSource(1))},
+				Solve:    rules.Solve{MaxNodes: 1},
+				MaxDeals: 2,
+			},
+			Input:  poc.StartGame{WinnableOnly: true},
+			Result: assert.New().Error.Category.Uint8(assert.Equals(poc.UnavailableError)),
+		},
+		{
+			Desc: "Any deal",
+			Command: rules.WinnableDeal{
+				Shuffle: rules.Shuffle{Source: rand.New(rand.NewSource(1))},
+				Solve:   rules.Solve{MaxNodes: 1},
+			},
+			Result: assert.New().NoError().
+				StartGame.SavedGameDetail.Board.Piles.Nth(0).Length(assert.Equals(24)),
+		},
+	}.Run(t)
+}
+
+// deal shuffles a fresh Klondike game for the solver.
+func deal(t *testing.T, seed int64) poc.SavedGameDetail {
+	start, err := rules.Shuffle{Source: rand.New(rand.NewSource(seed))}.CallStartGame(context.Background(), poc.StartGame{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return start.SavedGameDetail
+}
+
+func TestSolveCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := rules.Solve{}.CallSolveGame(ctx, poc.SolveGame{SavedGameDetail: deal(t, 1)})
+	if e, ok := err.(poc.Error); !ok || e.Category != poc.UnavailableError {
+		t.Errorf("expected an unavailable error but got %v", err)
+	}
+}
+
+func TestSolveTimeout(t *testing.T) {
+	solved, err := rules.Solve{Timeout: time.Nanosecond}.CallSolveGame(context.Background(), poc.SolveGame{SavedGameDetail: deal(t, 2)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if solved.Solvability != poc.SearchLimitReached {
+		t.Errorf("expected the search limit to be reached but got %d after %d nodes", solved.Solvability, solved.NodesSearched)
+	}
+	if len(solved.Solution) != 0 {
+		t.Errorf("expected no solution but got %d move groups", len(solved.Solution))
+	}
+}
+
+// TestWinnableDealTimeout checks that the solver's timeout covers every deal
+// together rather than each one.
+func TestWinnableDealTimeout(t *testing.T) {
+	const timeout = 20 * time.Millisecond
+	deal := rules.WinnableDeal{
+		Shuffle:  rules.Shuffle{Source: rand.New(rand.NewSource(22))}, // slow to solve
+		Solve:    rules.Solve{Timeout: timeout},
+		MaxDeals: 10,
+	}
+	began := time.Now()
+	_, err := deal.CallStartGame(context.Background(), poc.StartGame{WinnableOnly: true})
+	if elapsed := time.Since(began); elapsed > 3*timeout {
+		t.Errorf("dealing took %s with a timeout of %s (%v)", elapsed, timeout, err)
+	}
+}
@@ -0,0 +1,246 @@
+package rules
+
+import (
+	"errors"
+
+	"github.com/slcjordan/poc"
+)
+
+// ErrSpiderSuits means Spider was asked for with a number of suits other than
+// 1, 2 or 4.
+var ErrSpiderSuits = errors.New("spider is played with 1, 2 or 4 suits")
+
+// Spider piles: the stock, ten tableau piles and eight foundations, one for
+// each completed run.
+const (
+	spiderTableau    = 1
+	spiderFoundation = 11
+	spiderPiles      = 19
+)
+
+// Spider points.
+const (
+	spiderStart = 500
+	spiderMove  = -1
+	spiderRun   = 100
+)
+
+// spiderSuits checks the number of suits. 0 means all four.
+func spiderSuits(suits int32) (int32, error) {
+	switch suits {
+	case 0:
+		return 4, nil
+	case 1, 2, 4:
+		return suits, nil
+	}
+	return suits, ErrSpiderSuits
+}
+
+// spiderDeck lists two decks worth of cards made from the first suits of
+// Spades, Hearts, Clubs and Diamonds.
+func spiderDeck(suits int32) []poc.PositionedCard {
+	order := []poc.Suit{poc.Spades, poc.Hearts, poc.Clubs, poc.Diamonds}[:suits]
+	cards := make([]poc.PositionedCard, 0, 104)
+	for len(cards) < 104 {
+		for _, suit := range order {
+			for index := poc.Ace; index <= poc.King; index++ {
+				cards = append(cards, poc.PositionedCard{Card: poc.Card{Suit: suit, Index: index}})
+			}
+		}
+	}
+	return cards
+}
+
+// spiderDeal deals 54 cards to the tableau, six to each of the first four
+// piles and five to the rest, and leaves 50 in the stock.
+func spiderDeal(cards []poc.PositionedCard) [][]poc.PositionedCard {
+	piles := make([][]poc.PositionedCard, spiderPiles)
+	next := 0
+	for i := 0; i < 10; i++ {
+		size := 5
+		if i < 4 {
+			size = 6
+		}
+		piles[spiderTableau+i] = cards[next : next+size]
+		next += size
+	}
+	piles[0] = cards[next:]
+	return piles
+}
+
+// spiderRunStart finds where the run of face up cards of one suit, each one
+// lower than the last, starts at the top of a pile.
+func spiderRunStart(pile []poc.PositionedCard) int {
+	start := len(pile) - 1
+	for start > 0 {
+		below, above := pile[start-1], pile[start]
+		if below.Position&poc.FaceUp == 0 || below.Card.Suit != above.Card.Suit || below.Card.Index != above.Card.Index+1 {
+			break
+		}
+		start--
+	}
+	return start
+}
+
+// spiderMoves lists the moves of a Spider game. Any part of a run may be moved
+// onto a card one higher of any suit or into an empty pile, a whole run from
+// King to Ace goes to a foundation and the stock deals a card onto every
+// tableau pile as long as none are empty.
+func spiderMoves(game poc.SavedGameDetail) [][]poc.Move {
+	piles := game.Board.Piles
+	stock := piles[0]
+	tableau := piles[spiderTableau:spiderFoundation]
+
+	var result [][]poc.Move
+	var empty []int
+	for i, pile := range tableau {
+		if len(pile) < 1 {
+			empty = append(empty, i)
+		}
+	}
+	for i, pile := range tableau {
+		pileNum := spiderTableau + i
+		if len(pile) < 1 {
+			continue
+		}
+		top := pile[len(pile)-1]
+		if top.Position&poc.FaceUp == 0 { // flip over top face-down card
+			result = append(result, []poc.Move{{
+				OldPileNum:      pileNum,
+				OldPileIndex:    len(pile) - 1,
+				OldPilePosition: top.Position,
+				NewPileNum:      pileNum,
+				NewPileIndex:    len(pile) - 1,
+				NewPilePosition: top.Position | poc.FaceUp,
+			}})
+			continue
+		}
+		start := spiderRunStart(pile)
+		if len(pile)-start >= 13 && top.Card.Index == poc.Ace { // remove a whole run
+			for f := spiderFoundation; f < spiderPiles; f++ {
+				if len(piles[f]) == 0 {
+					result = append(result, moveCards(piles, pileNum, len(pile)-13, f))
+					break
+				}
+			}
+		}
+		for idx := start; idx < len(pile); idx++ {
+			card := pile[idx]
+			for j, other := range tableau {
+				if j == i || len(other) < 1 {
+					continue
+				}
+				dest := other[len(other)-1]
+				if dest.Position&poc.FaceUp != 0 && dest.Card.Index == card.Card.Index+1 {
+					result = append(result, moveCards(piles, pileNum, idx, spiderTableau+j))
+				}
+			}
+			if idx > 0 { // moving a whole pile into an empty one changes nothing
+				for _, j := range empty {
+					result = append(result, moveCards(piles, pileNum, idx, spiderTableau+j))
+				}
+			}
+		}
+	}
+
+	if len(stock) >= len(tableau) && len(empty) == 0 { // deal a card onto every tableau pile
+		var moves []poc.Move
+		for i := range tableau {
+			card := stock[len(stock)-1-i]
+			moves = append(moves, poc.Move{
+				OldPileNum:      0,
+				OldPileIndex:    len(stock) - 1 - i,
+				OldPilePosition: card.Position,
+				NewPileNum:      spiderTableau + i,
+				NewPileIndex:    len(tableau[i]),
+				NewPilePosition: card.Position | poc.FaceUp,
+			})
+		}
+		result = append(result, moves)
+	}
+	return result
+}
+
+// spiderPoints scores a Spider move group. Every move but a flip costs a
+// point and every completed run earns 100.
+func spiderPoints(moves []poc.Move) int32 {
+	if len(moves) < 1 {
+		return 0
+	}
+	first := moves[0]
+	switch {
+	case first.OldPileNum == first.NewPileNum:
+		return 0
+	case first.NewPileNum >= spiderFoundation:
+		return spiderMove + spiderRun
+	}
+	return spiderMove
+}
+
+// spiderWon reports whether all eight runs have been completed.
+func spiderWon(game poc.SavedGameDetail) bool {
+	for _, pile := range game.Board.Piles[spiderFoundation:] {
+		if len(pile) < 13 {
+			return false
+		}
+	}
+	return true
+}
+
+func init() {
+	Register("spider", spider{})
+}
+
+// spider is the Spider ruleset.
+type spider struct{}
+
+func (s spider) Piles(variant poc.Variant) int {
+	return spiderPiles
+}
+
+func (s spider) Seed(random int64) int64 {
+	return random
+}
+
+// Setup checks the number of suits. 0 means all four.
+func (s spider) Setup(variant poc.Variant, seed int64) (poc.Variant, error) {
+	suits, err := spiderSuits(variant.Suits)
+	if err != nil {
+		return variant, err
+	}
+	variant.Suits = suits
+	return variant, nil
+}
+
+func (s spider) Shuffle(variant poc.Variant, seed int64) []poc.PositionedCard {
+	return fisherYates(spiderDeck(variant.Suits), seed)
+}
+
+func (s spider) Deal(variant poc.Variant, cards []poc.PositionedCard) poc.Board {
+	return poc.Board{Piles: spiderDeal(cards)}
+}
+
+func (s spider) Moves(game poc.SavedGameDetail) [][]poc.Move {
+	return spiderMoves(game)
+}
+
+func (s spider) Validate(game poc.SavedGameDetail, moves []poc.Move) error {
+	return search(s.Moves(game), moves)
+}
+
+// Start gives Spider games their starting 500 points.
+func (s spider) Start(variant poc.Variant) int32 {
+	return spiderStart
+}
+
+func (s spider) Points(variant poc.Variant, moves []poc.Move) int32 {
+	return spiderPoints(moves)
+}
+
+func (s spider) Won(game poc.SavedGameDetail) bool {
+	return spiderWon(game)
+}
+
+func (s spider) Stuck(game poc.SavedGameDetail, limit int) bool {
+	return stockStuck(game, limit)
+}
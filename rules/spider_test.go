@@ -0,0 +1,112 @@
+package rules_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/slcjordan/poc"
+	"github.com/slcjordan/poc/pipeline"
+	"github.com/slcjordan/poc/rules"
+	"github.com/slcjordan/poc/test/assert"
+	"github.com/slcjordan/poc/test/harness"
+	"github.com/slcjordan/poc/test/logger"
+)
+
+// spider returns an empty Spider board.
+func spider() poc.Board {
+	return poc.Board{Piles: make([][]poc.PositionedCard, 19)}
+}
+
+func TestSpider(t *testing.T) {
+	logger.RegisterVerbose(t)
+	twoSuits := poc.Variant{Family: poc.Spider, Suits: 2}
+
+	run := spider() // a whole run of spades is ready to be removed
+	for index := poc.King; index >= poc.Ace; index-- {
+		run.Piles[1] = append(run.Piles[1], poc.PositionedCard{
+			Position: poc.FaceUp,
+			Card:     poc.Card{Suit: poc.Spades, Index: index},
+		})
+	}
+	removal := []poc.Move{}
+	for i := range run.Piles[1] {
+		removal = append(removal, poc.Move{
+			OldPileNum:      1,
+			OldPileIndex:    i,
+			OldPilePosition: poc.FaceUp,
+			NewPileNum:      11,
+			NewPileIndex:    i,
+			NewPilePosition: poc.FaceUp,
+		})
+	}
+	finished := spider()
+	for f := 11; f < 19; f++ {
+		finished.Piles[f] = run.Piles[1]
+	}
+
+	harness.StartGame{
+		{
+			Desc: "Deal ten columns and leave 50 cards in the stock",
+			Command: pipeline.StartGame{
+				rules.Shuffle{rand.New(rand.NewSource(0))},
+				rules.NextMove{},
+			},
+			Input: poc.StartGame{Variant: twoSuits},
+			Result: func() *assert.Assertion {
+				a := assert.New().NoError()
+				a.StartGame.SavedGameDetail.Board.Piles.Length(assert.Equals(19))
+				a.StartGame.SavedGameDetail.Board.Piles.Nth(0).Length(assert.Equals(50))
+				a.StartGame.SavedGameDetail.Board.Piles.Nth(1).Length(assert.Equals(6))
+				a.StartGame.SavedGameDetail.Board.Piles.Nth(10).Length(assert.Equals(5))
+				return a.StartGame.SavedGameDetail.PossibleNextMoves.Length(assert.Equals(11)) // ten flips and a deal
+			}(),
+		},
+		{
+			Desc:    "Spider is played with 1, 2 or 4 suits",
+			Command: rules.Shuffle{rand.New(rand.NewSource(0))},
+			Input:   poc.StartGame{Variant: poc.Variant{Family: poc.Spider, Suits: 3}},
+			Result:  assert.New().Error.Category.Uint8(assert.Equals(poc.MalformedError)),
+		},
+		{
+			Desc:    "A whole run can be removed",
+			Command: rules.NextMove{},
+			Input:   poc.StartGame{SavedGameDetail: poc.SavedGameDetail{Board: run, Variant: twoSuits}},
+			Result: func() *assert.Assertion {
+				a := assert.New().NoError()
+				a.StartGame.SavedGameDetail.PossibleNextMoves.Nth(0).Length(assert.Equals(13))
+				return a.StartGame.SavedGameDetail.PossibleNextMoves.Nth(0).Nth(0).NewPileNum(assert.Equals(11))
+			}(),
+		},
+		{
+			Desc:    "All eight runs removed",
+			Command: rules.Status{},
+			Input:   poc.StartGame{SavedGameDetail: poc.SavedGameDetail{Board: finished, Variant: twoSuits}},
+			Result: assert.New().NoError().
+				StartGame.SavedGameDetail.Status.Uint8(assert.Equals(poc.Won)),
+		},
+	}.Run(t)
+
+	harness.PerformMove{
+		{
+			Desc:    "A removed run scores 100 less the move",
+			Command: rules.Score{},
+			Input: poc.PerformMove{
+				Next: removal,
+				SavedGameDetail: poc.SavedGameDetail{
+					Board:   poc.Board{Score: 500},
+					Variant: twoSuits,
+				},
+			},
+			Result: assert.New().NoError().PerformMove.SavedGameDetail.Board.Score(assert.Equals(599)),
+		},
+	}.Run(t)
+
+	harness.Hint{
+		{
+			Desc:    "Hints are only given for Klondike",
+			Command: rules.Hint{},
+			Input:   poc.Hint{SavedGameDetail: poc.SavedGameDetail{Board: run, Variant: twoSuits}},
+			Result:  assert.New().Error.Category.Uint8(assert.Equals(poc.UnimplementedError)),
+		},
+	}.Run(t)
+}
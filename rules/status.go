@@ -0,0 +1,160 @@
+package rules
+
+import (
+	"context"
+	"errors"
+
+	"github.com/slcjordan/poc"
+)
+
+// ErrGameOver means the user tried to play a game that has already finished.
+var ErrGameOver = errors.New("game is over")
+
+// isStockMove reports whether a move group only draws from the stock or
+// returns the talon to it.
+func isStockMove(moves []poc.Move) bool {
+	for _, m := range moves {
+		if m.OldPileNum > 1 || m.NewPileNum > 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// stuckLimit is how many positions stuck looks through before it gives up
+// and leaves the game in progress, unless Status says otherwise.
+const stuckLimit = 5000
+
+// isShuffle reports whether a Klondike move group only cycles the stock or
+// moves face up cards between the tableau and foundations. Such moves never
+// turn a card over or take one off the talon, and can go round in circles.
+func isShuffle(moves []poc.Move) bool {
+	for _, m := range moves {
+		if m.OldPileNum == m.NewPileNum || (m.OldPileNum == 1 && m.NewPileNum > 1) {
+			return false
+		}
+	}
+	return true
+}
+
+// stockStuck reports whether there is nothing left to do but cycle through
+// the stock.
+func stockStuck(game poc.SavedGameDetail, limit int) bool {
+	return goingNowhere(game, isStockMove, limit)
+}
+
+// goingNowhere reports whether play can only go round in circles: every
+// position reachable through quiet move groups offers nothing but more quiet
+// move groups and none of them is won. Positions are told apart by their
+// hash, and by how many times the deck has been through when that is limited.
+// Play is given the benefit of the doubt once limit positions have been seen,
+// so a limit of 1 only finds games with no moves or nothing but quiet moves
+// that lead straight back to where they started.
+func goingNowhere(game poc.SavedGameDetail, quiet func([]poc.Move) bool, limit int) bool {
+	type position struct {
+		hash     poc.Hash
+		recycled int32
+	}
+	positionOf := func(game poc.SavedGameDetail) position {
+		if game.Variant.MaxTimesThroughDeck > 0 {
+			return position{Hash(game.Board), timesThroughDeck(game.History)}
+		}
+		return position{hash: Hash(game.Board)}
+	}
+	seen := map[position]bool{positionOf(game): true}
+	queue := []poc.SavedGameDetail{game}
+	for len(queue) > 0 {
+		curr := queue[0]
+		queue = queue[1:]
+		if won(curr) {
+			return false
+		}
+		for _, moves := range nextMoves(curr) {
+			if !quiet(moves) {
+				return false
+			}
+			piles, err := apply(curr.Board.Piles, moves)
+			if err != nil {
+				return false
+			}
+			next := curr
+			next.Board.Piles = piles
+			next.History = append(curr.History[:len(curr.History):len(curr.History)], moves)
+			key := positionOf(next)
+			if seen[key] {
+				continue
+			}
+			if len(seen) >= limit {
+				return false
+			}
+			seen[key] = true
+			queue = append(queue, next)
+		}
+	}
+	return true
+}
+
+// status works out the status of a game from its board, looking through at
+// most limit positions to tell whether it is stuck. Resigning is the only
+// status that can't be seen on the board, so it is kept as is.
+func status(game poc.SavedGameDetail, limit int) poc.GameStatus {
+	switch {
+	case game.Status == poc.Resigned:
+		return poc.Resigned
+	case won(game):
+		return poc.Won
+	case stuck(game, limit):
+		return poc.Stuck
+	}
+	return poc.InProgress
+}
+
+// Status keeps SavedGameDetail.Status up to date. MaxPositions caps how many
+// positions are looked through on each call to tell whether a game is stuck;
+// 0 means stuckLimit.
+type Status struct {
+	MaxPositions int
+}
+
+func (s Status) limit() int {
+	if s.MaxPositions < 1 {
+		return stuckLimit
+	}
+	return s.MaxPositions
+}
+
+// CallStartGame checks the deal.
+func (s Status) CallStartGame(ctx context.Context, game poc.StartGame) (poc.StartGame, error) {
+	game.SavedGameDetail.Status = status(game.SavedGameDetail, s.limit())
+	return game, nil
+}
+
+// CallPerformMove checks the board after the move.
+func (s Status) CallPerformMove(ctx context.Context, move poc.PerformMove) (poc.PerformMove, error) {
+	move.SavedGameDetail.Status = status(move.SavedGameDetail, s.limit())
+	return move, nil
+}
+
+// CallUndoMove checks the board after the undo.
+func (s Status) CallUndoMove(ctx context.Context, game poc.UndoMove) (poc.UndoMove, error) {
+	game.SavedGameDetail.Status = status(game.SavedGameDetail, s.limit())
+	return game, nil
+}
+
+// CallRedoMove checks the board after the redo.
+func (s Status) CallRedoMove(ctx context.Context, game poc.RedoMove) (poc.RedoMove, error) {
+	game.SavedGameDetail.Status = status(game.SavedGameDetail, s.limit())
+	return game, nil
+}
+
+// Resign ends a game that is still in progress.
+type Resign struct{}
+
+// CallResignGame marks the game as resigned.
+func (r Resign) CallResignGame(ctx context.Context, game poc.ResignGame) (poc.ResignGame, error) {
+	if game.SavedGameDetail.Status != poc.InProgress {
+		return game, poc.Error{Actual: ErrGameOver, Category: poc.SemanticError}
+	}
+	game.SavedGameDetail.Status = poc.Resigned
+	return game, nil
+}
@@ -0,0 +1,143 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/slcjordan/poc"
+	"github.com/slcjordan/poc/rules"
+	"github.com/slcjordan/poc/test/assert"
+	"github.com/slcjordan/poc/test/harness"
+	"github.com/slcjordan/poc/test/logger"
+)
+
+func TestStatus(t *testing.T) {
+	logger.RegisterVerbose(t)
+	finished := klondike()
+	for suit := poc.Hearts; suit <= poc.Spades; suit++ {
+		for index := poc.Ace; index <= poc.King; index++ {
+			finished.Piles[suit+8] = append(finished.Piles[suit+8], poc.PositionedCard{
+				Position: poc.FaceUp,
+				Card:     poc.Card{Suit: suit, Index: index},
+			})
+		}
+	}
+	blocked := klondike() // nothing in the stock can be played
+	blocked.Piles[0] = []poc.PositionedCard{
+		{Card: poc.Card{Suit: poc.Spades, Index: poc.Nine}},
+		{Card: poc.Card{Suit: poc.Clubs, Index: poc.Nine}},
+	}
+	blocked.Piles[1] = []poc.PositionedCard{
+		{Position: poc.FaceUp, Card: poc.Card{Suit: poc.Hearts, Index: poc.Two}},
+	}
+	playable := clone(blocked) // the ace of hearts is buried in the stock
+	playable.Piles[0] = append([]poc.PositionedCard{
+		{Card: poc.Card{Suit: poc.Hearts, Index: poc.Ace}},
+	}, blocked.Piles[0]...)
+	bouncing := klondike() // the two of hearts can only go back and forth
+	bouncing.Piles[2] = []poc.PositionedCard{
+		{Card: poc.Card{Suit: poc.Diamonds, Index: poc.Nine}},
+		{Position: poc.FaceUp, Card: poc.Card{Suit: poc.Clubs, Index: poc.Three}},
+	}
+	bouncing.Piles[9] = []poc.PositionedCard{
+		{Position: poc.FaceUp, Card: poc.Card{Suit: poc.Hearts, Index: poc.Ace}},
+		{Position: poc.FaceUp, Card: poc.Card{Suit: poc.Hearts, Index: poc.Two}},
+	}
+	uncovering := clone(bouncing) // moving the three of clubs turns over a card
+	uncovering.Piles[3] = []poc.PositionedCard{
+		{Card: poc.Card{Suit: poc.Spades, Index: poc.Five}},
+		{Position: poc.FaceUp, Card: poc.Card{Suit: poc.Diamonds, Index: poc.Four}},
+	}
+
+	harness.StartGame{
+		{
+			Desc:    "All foundations built to King",
+			Command: rules.Status{},
+			Input:   poc.StartGame{SavedGameDetail: poc.SavedGameDetail{Board: finished}},
+			Result: assert.New().NoError().
+				StartGame.SavedGameDetail.Status.Uint8(assert.Equals(poc.Won)),
+		},
+		{
+			Desc:    "No moves",
+			Command: rules.Status{},
+			Result: assert.New().NoError().
+				StartGame.SavedGameDetail.Status.Uint8(assert.Equals(poc.Stuck)),
+		},
+		{
+			Desc:    "Only stock cycles",
+			Command: rules.Status{},
+			Input:   poc.StartGame{SavedGameDetail: poc.SavedGameDetail{Board: blocked}},
+			Result: assert.New().NoError().
+				StartGame.SavedGameDetail.Status.Uint8(assert.Equals(poc.Stuck)),
+		},
+		{
+			Desc:    "A play is reachable through the stock",
+			Command: rules.Status{},
+			Input:   poc.StartGame{SavedGameDetail: poc.SavedGameDetail{Board: playable}},
+			Result: assert.New().NoError().
+				StartGame.SavedGameDetail.Status.Uint8(assert.Equals(poc.InProgress)),
+		},
+		{
+			Desc:    "Stock can not be recycled to reach a play",
+			Command: rules.Status{},
+			Input: poc.StartGame{SavedGameDetail: poc.SavedGameDetail{
+				Board: func() poc.Board {
+					board := clone(playable)
+					board.Piles[1] = append(board.Piles[0][:1:1], board.Piles[1]...)
+					board.Piles[0] = board.Piles[0][1:]
+					return board
+				}(),
+				Variant: poc.Variant{MaxTimesThroughDeck: 1},
+			}},
+			Result: assert.New().NoError().
+				StartGame.SavedGameDetail.Status.Uint8(assert.Equals(poc.Stuck)),
+		},
+		{
+			Desc:    "Only a card moving on and off a foundation",
+			Command: rules.Status{},
+			Input:   poc.StartGame{SavedGameDetail: poc.SavedGameDetail{Board: bouncing}},
+			Result: assert.New().NoError().
+				StartGame.SavedGameDetail.Status.Uint8(assert.Equals(poc.Stuck)),
+		},
+		{
+			Desc:    "Giving up before the foundation bounces back",
+			Command: rules.Status{MaxPositions: 1},
+			Input:   poc.StartGame{SavedGameDetail: poc.SavedGameDetail{Board: bouncing}},
+			Result: assert.New().NoError().
+				StartGame.SavedGameDetail.Status.Uint8(assert.Equals(poc.InProgress)),
+		},
+		{
+			Desc:    "Moving a card uncovers another",
+			Command: rules.Status{},
+			Input:   poc.StartGame{SavedGameDetail: poc.SavedGameDetail{Board: uncovering}},
+			Result: assert.New().NoError().
+				StartGame.SavedGameDetail.Status.Uint8(assert.Equals(poc.InProgress)),
+		},
+	}.Run(t)
+	harness.PerformMove{
+		{
+			Desc:    "Moves on finished games are rejected",
+			Command: rules.Validate{},
+			Input: poc.PerformMove{
+				Next: []poc.Move{
+					{OldPileNum: 0, OldPileIndex: 1, NewPileNum: 1, NewPileIndex: 1, NewPilePosition: poc.FaceUp},
+				},
+				SavedGameDetail: poc.SavedGameDetail{Board: blocked, Status: poc.Stuck},
+			},
+			Result: assert.New().Error.Category.Uint8(assert.Equals(poc.SemanticError)),
+		},
+	}.Run(t)
+	harness.ResignGame{
+		{
+			Desc:    "Resign a game in progress",
+			Command: rules.Resign{},
+			Result: assert.New().NoError().
+				ResignGame.SavedGameDetail.Status.Uint8(assert.Equals(poc.Resigned)),
+		},
+		{
+			Desc:    "Resign a finished game",
+			Command: rules.Resign{},
+			Input:   poc.ResignGame{SavedGameDetail: poc.SavedGameDetail{Status: poc.Won}},
+			Result:  assert.New().Error.Category.Uint8(assert.Equals(poc.SemanticError)),
+		},
+	}.Run(t)
+}
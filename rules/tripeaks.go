@@ -0,0 +1,179 @@
+package rules
+
+import (
+	"github.com/slcjordan/poc"
+)
+
+// TriPeaks piles: the stock, the talon and the 28 slots of the three peaks
+// from the top row down. Cards are played onto the talon, so there are no
+// foundations.
+const (
+	tripeaksTableau = 2
+	tripeaksBase    = 20 // the first slot of the bottom row
+	tripeaksPiles   = 30
+)
+
+// TriPeaks points.
+const (
+	tripeaksCard = 5
+	tripeaksPeak = 15
+)
+
+// tripeaksSlots lays out three peaks of three rows, three cards wide at
+// their top, over a bottom row of ten cards.
+func tripeaksSlots() []poc.Slot {
+	rows := [][]int{
+		{3, 9, 15},
+		{2, 4, 8, 10, 14, 16},
+		{1, 3, 5, 7, 9, 11, 13, 15, 17},
+		{0, 2, 4, 6, 8, 10, 12, 14, 16, 18},
+	}
+	var slots []poc.Slot
+	for row, cols := range rows {
+		for _, col := range cols {
+			slots = append(slots, poc.Slot{
+				PileNum: tripeaksTableau + len(slots),
+				Row:     row,
+				Column:  col,
+			})
+		}
+	}
+	return slots
+}
+
+// tripeaksDeal deals 28 cards into the peaks with only the bottom row face
+// up, turns one card onto the talon and leaves the rest in the stock.
+func tripeaksDeal(cards []poc.PositionedCard) [][]poc.PositionedCard {
+	piles := make([][]poc.PositionedCard, tripeaksPiles)
+	for i, card := range cards[:28] {
+		if tripeaksTableau+i >= tripeaksBase {
+			card.Position |= poc.FaceUp
+		}
+		piles[tripeaksTableau+i] = []poc.PositionedCard{card}
+	}
+	talon := cards[28]
+	talon.Position |= poc.FaceUp
+	piles[1] = []poc.PositionedCard{talon}
+	piles[0] = cards[29:]
+	return piles
+}
+
+// tripeaksMoves lists the moves of a TriPeaks game. Uncovered cards are
+// turned face up and may be played onto the talon when they are one higher
+// or lower than its top card, with Kings and Aces next to each other. The
+// stock is dealt one card at a time and is not recycled.
+func tripeaksMoves(game poc.SavedGameDetail) [][]poc.Move {
+	piles := game.Board.Piles
+	talon := piles[1]
+	var result [][]poc.Move
+
+	for pileNum := tripeaksTableau; pileNum < tripeaksPiles; pileNum++ {
+		pile := piles[pileNum]
+		if len(pile) < 1 || covered(game.Board, pileNum) {
+			continue
+		}
+		card := pile[0]
+		if card.Position&poc.FaceUp == 0 { // flip over an uncovered card
+			result = append(result, []poc.Move{{
+				OldPileNum:      pileNum,
+				OldPileIndex:    0,
+				OldPilePosition: card.Position,
+				NewPileNum:      pileNum,
+				NewPileIndex:    0,
+				NewPilePosition: card.Position | poc.FaceUp,
+			}})
+			continue
+		}
+		if len(talon) < 1 {
+			continue
+		}
+		diff := (int(card.Card.Index) - int(talon[len(talon)-1].Card.Index) + 13) % 13
+		if diff == 1 || diff == 12 {
+			result = append(result, moveCards(piles, pileNum, 0, 1))
+		}
+	}
+
+	if len(piles[0]) > 0 {
+		result = append(result, drawCard(piles))
+	}
+	return result
+}
+
+// tripeaksPoints scores a TriPeaks move group. Every card played onto the
+// talon earns 5 points and the top of each peak earns 15 more.
+func tripeaksPoints(moves []poc.Move) int32 {
+	var result int32
+	for _, m := range moves {
+		if m.OldPileNum < tripeaksTableau || m.NewPileNum != 1 {
+			continue
+		}
+		result += tripeaksCard
+		if m.OldPileNum < tripeaksTableau+3 {
+			result += tripeaksPeak
+		}
+	}
+	return result
+}
+
+// tripeaksWon reports whether every peak has been cleared.
+func tripeaksWon(game poc.SavedGameDetail) bool {
+	for _, pile := range game.Board.Piles[tripeaksTableau:] {
+		if len(pile) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func init() {
+	Register("tripeaks", tripeaks{})
+}
+
+// tripeaks is the TriPeaks ruleset.
+type tripeaks struct{}
+
+func (p tripeaks) Piles(variant poc.Variant) int {
+	return tripeaksPiles
+}
+
+func (p tripeaks) Seed(random int64) int64 {
+	return random
+}
+
+func (p tripeaks) Setup(variant poc.Variant, seed int64) (poc.Variant, error) {
+	return variant, nil
+}
+
+func (p tripeaks) Shuffle(variant poc.Variant, seed int64) []poc.PositionedCard {
+	return fisherYates(deck(variant), seed)
+}
+
+// Deal deals the cards into overlapping slots.
+func (p tripeaks) Deal(variant poc.Variant, cards []poc.PositionedCard) poc.Board {
+	slots := tripeaksSlots()
+	return poc.Board{Piles: tripeaksDeal(cards), Slots: slots, Covers: coverRows(slots)}
+}
+
+func (p tripeaks) Moves(game poc.SavedGameDetail) [][]poc.Move {
+	return tripeaksMoves(game)
+}
+
+func (p tripeaks) Validate(game poc.SavedGameDetail, moves []poc.Move) error {
+	return search(p.Moves(game), moves)
+}
+
+func (p tripeaks) Start(variant poc.Variant) int32 {
+	return 0
+}
+
+func (p tripeaks) Points(variant poc.Variant, moves []poc.Move) int32 {
+	return tripeaksPoints(moves)
+}
+
+func (p tripeaks) Won(game poc.SavedGameDetail) bool {
+	return tripeaksWon(game)
+}
+
+func (p tripeaks) Stuck(game poc.SavedGameDetail, limit int) bool {
+	return stockStuck(game, limit)
+}
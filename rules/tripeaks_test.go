@@ -0,0 +1,113 @@
+package rules_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/slcjordan/poc"
+	"github.com/slcjordan/poc/rules"
+	"github.com/slcjordan/poc/test/assert"
+	"github.com/slcjordan/poc/test/harness"
+	"github.com/slcjordan/poc/test/logger"
+)
+
+func TestTriPeaks(t *testing.T) {
+	logger.RegisterVerbose(t)
+	tripeaks := poc.Variant{Family: poc.TriPeaks}
+	faceUp := func(suit poc.Suit, index poc.Index) poc.PositionedCard {
+		return poc.PositionedCard{Position: poc.FaceUp, Card: poc.Card{Suit: suit, Index: index}}
+	}
+
+	board := poc.Board{Piles: make([][]poc.PositionedCard, 30)} // an Ace can go on the King on the talon
+	board.Piles[1] = []poc.PositionedCard{faceUp(poc.Spades, poc.King)}
+	board.Piles[11] = []poc.PositionedCard{{Card: poc.Card{Suit: poc.Clubs, Index: poc.Five}}}
+	board.Piles[20] = []poc.PositionedCard{faceUp(poc.Hearts, poc.Ace)}
+	board.Piles[21] = []poc.PositionedCard{faceUp(poc.Hearts, poc.Three)}
+	board.Covers = []poc.Cover{{PileNum: 11, CoveredBy: 20}, {PileNum: 11, CoveredBy: 21}}
+	ace := []poc.Move{
+		{OldPileNum: 20, OldPileIndex: 0, OldPilePosition: poc.FaceUp, NewPileNum: 1, NewPileIndex: 1, NewPilePosition: poc.FaceUp},
+	}
+	blocked := clone(board)
+	blocked.Piles[20] = nil
+	uncovered := clone(board)
+	uncovered.Piles[20] = nil
+	uncovered.Piles[21] = nil
+
+	harness.StartGame{
+		{
+			Desc:    "Deal three peaks with the bottom row face up",
+			Command: rules.Shuffle{rand.New(rand.NewSource(0))},
+			Input:   poc.StartGame{Variant: tripeaks},
+			Result: func() *assert.Assertion {
+				a := assert.New().NoError()
+				a.StartGame.SavedGameDetail.Board.Piles.Length(assert.Equals(30))
+				a.StartGame.SavedGameDetail.Board.Piles.Nth(0).Length(assert.Equals(23))
+				a.StartGame.SavedGameDetail.Board.Piles.Nth(1).Length(assert.Equals(1))
+				a.StartGame.SavedGameDetail.Board.Piles.Nth(2).Nth(0).Position.Uint64(assert.Equals(0))
+				a.StartGame.SavedGameDetail.Board.Piles.Nth(20).Nth(0).Position.Uint64(assert.Equals(poc.FaceUp))
+				a.StartGame.SavedGameDetail.Board.Slots.Length(assert.Equals(28))
+				return a.StartGame.SavedGameDetail.Board.Covers.Length(assert.Equals(36))
+			}(),
+		},
+		{
+			Desc:    "Uncovered cards are turned face up",
+			Command: rules.NextMove{},
+			Input:   poc.StartGame{SavedGameDetail: poc.SavedGameDetail{Board: uncovered, Variant: tripeaks}},
+			Result: func() *assert.Assertion {
+				a := assert.New().NoError()
+				a.StartGame.SavedGameDetail.PossibleNextMoves.Length(assert.Equals(1))
+				return a.StartGame.SavedGameDetail.PossibleNextMoves.Nth(0).Nth(0).NewPilePosition.Uint64(assert.Equals(poc.FaceUp))
+			}(),
+		},
+		{
+			Desc:    "No cards left to play",
+			Command: rules.Status{},
+			Input:   poc.StartGame{SavedGameDetail: poc.SavedGameDetail{Board: blocked, Variant: tripeaks}},
+			Result: assert.New().NoError().
+				StartGame.SavedGameDetail.Status.Uint8(assert.Equals(poc.Stuck)),
+		},
+	}.Run(t)
+
+	harness.PerformMove{
+		{
+			Desc:    "Aces and Kings are next to each other",
+			Command: rules.Validate{},
+			Input: poc.PerformMove{
+				Next:            ace,
+				SavedGameDetail: poc.SavedGameDetail{Board: board, Variant: tripeaks},
+			},
+			Result: assert.New().NoError(),
+		},
+		{
+			Desc:    "Cards two apart can't be played",
+			Command: rules.Validate{},
+			Input: poc.PerformMove{
+				Next: []poc.Move{
+					{OldPileNum: 21, OldPileIndex: 0, OldPilePosition: poc.FaceUp, NewPileNum: 1, NewPileIndex: 1, NewPilePosition: poc.FaceUp},
+				},
+				SavedGameDetail: poc.SavedGameDetail{Board: board, Variant: tripeaks},
+			},
+			Result: assert.New().Error.Category.Uint8(assert.Equals(poc.SemanticError)),
+		},
+		{
+			Desc:    "Every card played earns 5 points",
+			Command: rules.Score{},
+			Input: poc.PerformMove{
+				Next:            ace,
+				SavedGameDetail: poc.SavedGameDetail{Variant: tripeaks},
+			},
+			Result: assert.New().NoError().PerformMove.SavedGameDetail.Board.Score(assert.Equals(5)),
+		},
+		{
+			Desc:    "The top of a peak earns 15 more",
+			Command: rules.Score{},
+			Input: poc.PerformMove{
+				Next: []poc.Move{
+					{OldPileNum: 3, OldPileIndex: 0, OldPilePosition: poc.FaceUp, NewPileNum: 1, NewPileIndex: 20, NewPilePosition: poc.FaceUp},
+				},
+				SavedGameDetail: poc.SavedGameDetail{Variant: tripeaks},
+			},
+			Result: assert.New().NoError().PerformMove.SavedGameDetail.Board.Score(assert.Equals(20)),
+		},
+	}.Run(t)
+}
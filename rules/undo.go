@@ -0,0 +1,95 @@
+package rules
+
+import (
+	"context"
+	"errors"
+
+	"github.com/slcjordan/poc"
+)
+
+// Undo errors.
+var (
+	ErrUndoNotAllowed = errors.New("undo is not allowed in this variant")
+	ErrNothingToUndo  = errors.New("there are no moves to undo")
+	ErrNothingToRedo  = errors.New("there are no undone moves to redo")
+)
+
+// reverse turns a move group into the group that takes it back. Card flips are
+// reversed along with everything else since they are moves within a pile.
+func reverse(moves []poc.Move) []poc.Move {
+	result := make([]poc.Move, len(moves))
+	for i, m := range moves {
+		result[i] = poc.Move{
+			OldPileNum:      m.NewPileNum,
+			OldPileIndex:    m.NewPileIndex,
+			OldPilePosition: m.NewPilePosition,
+			NewPileNum:      m.OldPileNum,
+			NewPileIndex:    m.OldPileIndex,
+			NewPilePosition: m.OldPilePosition,
+		}
+	}
+	return result
+}
+
+// Undo takes back the last move group.
+type Undo struct{}
+
+// CallUndoMove moves the last History group onto the Redo stack. Stuck and won
+// games may be undone but resigned games may not.
+func (u Undo) CallUndoMove(ctx context.Context, game poc.UndoMove) (poc.UndoMove, error) {
+	detail := game.SavedGameDetail
+	switch {
+	case !detail.Variant.AllowUndo:
+		return game, poc.Error{Actual: ErrUndoNotAllowed, Category: poc.SemanticError}
+	case detail.Status == poc.Resigned:
+		return game, poc.Error{Actual: ErrGameOver, Category: poc.SemanticError}
+	case len(detail.History) < 1:
+		return game, poc.Error{Actual: ErrNothingToUndo, Category: poc.SemanticError}
+	}
+	last := detail.History[len(detail.History)-1]
+	piles, err := apply(detail.Board.Piles, reverse(last))
+	if err != nil {
+		return game, poc.Error{Actual: err, Category: poc.UnknownError}
+	}
+	game.SavedGameDetail.Board.Piles = piles
+
+	game.SavedGameDetail.History = make([][]poc.Move, len(detail.History)-1)
+	copy(game.SavedGameDetail.History, detail.History)
+	if len(detail.Hashes) > len(game.SavedGameDetail.History) {
+		game.SavedGameDetail.Hashes = detail.Hashes[:len(game.SavedGameDetail.History):len(game.SavedGameDetail.History)]
+	}
+	redo := make([][]poc.Move, len(detail.Redo), len(detail.Redo)+1)
+	copy(redo, detail.Redo)
+	game.SavedGameDetail.Redo = append(redo, last)
+	return game, nil
+}
+
+// Redo makes the last undone move group again.
+type Redo struct{}
+
+// CallRedoMove moves the top of the Redo stack back onto History.
+func (r Redo) CallRedoMove(ctx context.Context, game poc.RedoMove) (poc.RedoMove, error) {
+	detail := game.SavedGameDetail
+	switch {
+	case !detail.Variant.AllowUndo:
+		return game, poc.Error{Actual: ErrUndoNotAllowed, Category: poc.SemanticError}
+	case detail.Status == poc.Resigned:
+		return game, poc.Error{Actual: ErrGameOver, Category: poc.SemanticError}
+	case len(detail.Redo) < 1:
+		return game, poc.Error{Actual: ErrNothingToRedo, Category: poc.SemanticError}
+	}
+	next := detail.Redo[len(detail.Redo)-1]
+	piles, err := apply(detail.Board.Piles, next)
+	if err != nil {
+		return game, poc.Error{Actual: err, Category: poc.UnknownError}
+	}
+	game.SavedGameDetail.Hashes = nextHashes(detail, piles, next)
+	game.SavedGameDetail.Board.Piles = piles
+
+	game.SavedGameDetail.Redo = make([][]poc.Move, len(detail.Redo)-1)
+	copy(game.SavedGameDetail.Redo, detail.Redo)
+	history := make([][]poc.Move, len(detail.History), len(detail.History)+1)
+	copy(history, detail.History)
+	game.SavedGameDetail.History = append(history, next)
+	return game, nil
+}
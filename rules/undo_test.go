@@ -0,0 +1,137 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/slcjordan/poc"
+	"github.com/slcjordan/poc/rules"
+	"github.com/slcjordan/poc/test/assert"
+	"github.com/slcjordan/poc/test/harness"
+	"github.com/slcjordan/poc/test/logger"
+)
+
+func TestUndo(t *testing.T) {
+	logger.RegisterVerbose(t)
+	king := poc.Card{Suit: poc.Spades, Index: poc.King}
+	five := poc.Card{Suit: poc.Hearts, Index: poc.Five}
+	kingMove := []poc.Move{{OldPileNum: 2, OldPileIndex: 1, OldPilePosition: poc.FaceUp, NewPileNum: 3, NewPileIndex: 0, NewPilePosition: poc.FaceUp}}
+	flip := []poc.Move{{OldPileNum: 2, OldPileIndex: 0, NewPileNum: 2, NewPileIndex: 0, NewPilePosition: poc.FaceUp}}
+
+	var moved poc.SavedGameDetail // the king has moved but the five is still face down
+	moved.Variant.AllowUndo = true
+	moved.Board = klondike()
+	moved.Board.Piles[2] = []poc.PositionedCard{{Card: five}}
+	moved.Board.Piles[3] = []poc.PositionedCard{{Position: poc.FaceUp, Card: king}}
+	moved.History = [][]poc.Move{kingMove}
+
+	flipped := moved
+	flipped.Board = clone(moved.Board)
+	flipped.Board.Piles[2] = []poc.PositionedCard{{Position: poc.FaceUp, Card: five}}
+	flipped.History = [][]poc.Move{kingMove, flip}
+
+	notAllowed := flipped
+	notAllowed.Variant.AllowUndo = false
+	resigned := flipped
+	resigned.Status = poc.Resigned
+	fresh := moved
+	fresh.History = nil
+
+	harness.UndoMove{
+		{
+			Desc:    "Undo a card flip",
+			Command: rules.Undo{},
+			Input:   poc.UndoMove{SavedGameDetail: flipped},
+			Result: func() *assert.Assertion {
+				a := assert.New().NoError()
+				a.UndoMove.SavedGameDetail.Board.Piles.Nth(2).Nth(0).Position.Uint64(assert.Equals(0))
+				a.UndoMove.SavedGameDetail.History.Length(assert.Equals(1))
+				a.UndoMove.SavedGameDetail.Redo.Length(assert.Equals(1))
+				return a.UndoMove.SavedGameDetail.Redo.Nth(0).Nth(0).NewPilePosition.Uint64(assert.Equals(poc.FaceUp))
+			}(),
+		},
+		{
+			Desc:    "Undo a move between piles",
+			Command: rules.Undo{},
+			Input:   poc.UndoMove{SavedGameDetail: moved},
+			Result: func() *assert.Assertion {
+				a := assert.New().NoError()
+				a.UndoMove.SavedGameDetail.Board.Piles.Nth(2).Length(assert.Equals(2))
+				a.UndoMove.SavedGameDetail.Board.Piles.Nth(2).Nth(1).Card.Index.Uint8(assert.Equals(poc.King))
+				a.UndoMove.SavedGameDetail.Board.Piles.Nth(3).Length(assert.Equals(0))
+				return a.UndoMove.SavedGameDetail.History.Length(assert.Equals(0))
+			}(),
+		},
+		{
+			Desc:    "Undo is not allowed",
+			Command: rules.Undo{},
+			Input:   poc.UndoMove{SavedGameDetail: notAllowed},
+			Result:  assert.New().Error.Category.Uint8(assert.Equals(poc.SemanticError)),
+		},
+		{
+			Desc:    "Resigned game",
+			Command: rules.Undo{},
+			Input:   poc.UndoMove{SavedGameDetail: resigned},
+			Result:  assert.New().Error.Category.Uint8(assert.Equals(poc.SemanticError)),
+		},
+		{
+			Desc:    "Nothing to undo",
+			Command: rules.Undo{},
+			Input:   poc.UndoMove{SavedGameDetail: fresh},
+			Result:  assert.New().Error.Category.Uint8(assert.Equals(poc.SemanticError)),
+		},
+		{
+			Desc:    "Undo takes back points and charges the penalty",
+			Command: rules.Score{},
+			Input: poc.UndoMove{SavedGameDetail: poc.SavedGameDetail{
+				Board:   poc.Board{Score: 20},
+				Variant: poc.Variant{AllowUndo: true, UndoPenalty: 2},
+				Redo:    [][]poc.Move{flip},
+			}},
+			Result: assert.New().NoError().UndoMove.SavedGameDetail.Board.Score(assert.Equals(13)),
+		},
+	}.Run(t)
+
+	redo := moved
+	redo.Redo = [][]poc.Move{flip}
+
+	harness.RedoMove{
+		{
+			Desc:    "Redo a card flip",
+			Command: rules.Redo{},
+			Input:   poc.RedoMove{SavedGameDetail: redo},
+			Result: func() *assert.Assertion {
+				a := assert.New().NoError()
+				a.RedoMove.SavedGameDetail.Board.Piles.Nth(2).Nth(0).Position.Uint64(assert.Equals(poc.FaceUp))
+				a.RedoMove.SavedGameDetail.History.Length(assert.Equals(2))
+				return a.RedoMove.SavedGameDetail.Redo.Length(assert.Equals(0))
+			}(),
+		},
+		{
+			Desc:    "Nothing to redo",
+			Command: rules.Redo{},
+			Input:   poc.RedoMove{SavedGameDetail: moved},
+			Result:  assert.New().Error.Category.Uint8(assert.Equals(poc.SemanticError)),
+		},
+		{
+			Desc:    "Redo awards points again",
+			Command: rules.Score{},
+			Input: poc.RedoMove{SavedGameDetail: poc.SavedGameDetail{
+				Board:   poc.Board{Score: 13},
+				History: [][]poc.Move{flip},
+			}},
+			Result: assert.New().NoError().RedoMove.SavedGameDetail.Board.Score(assert.Equals(18)),
+		},
+	}.Run(t)
+
+	harness.PerformMove{
+		{
+			Desc:    "A fresh move clears the redo stack",
+			Command: rules.Apply{},
+			Input: poc.PerformMove{
+				Next:            flip,
+				SavedGameDetail: redo,
+			},
+			Result: assert.New().NoError().PerformMove.SavedGameDetail.Redo.Length(assert.Equals(0)),
+		},
+	}.Run(t)
+}
@@ -0,0 +1,150 @@
+package rules
+
+import (
+	"github.com/slcjordan/poc"
+)
+
+// Sizes of the zobrist key table. Cards further into a pile, or in piles past
+// the table, have their keys worked out when they are needed.
+const (
+	zobristPiles   = 32
+	zobristIndexes = 32
+	zobristCards   = 256 // a suit below 8, an index below 16 and face up or down
+)
+
+// zobristKeys holds the keys of the cards most boards are made of.
+var zobristKeys = func() *[zobristPiles][zobristIndexes][zobristCards]poc.Hash {
+	var result [zobristPiles][zobristIndexes][zobristCards]poc.Hash
+	for pileNum := range result {
+		for pileIndex := range result[pileNum] {
+			for slot := range result[pileNum][pileIndex] {
+				card := poc.PositionedCard{
+					Position: poc.Position(slot & 1),
+					Card:     poc.Card{Suit: poc.Suit(slot >> 5), Index: poc.Index(slot >> 1 & 0x0f)},
+				}
+				result[pileNum][pileIndex][slot] = newZobristKey(pileNum, pileIndex, card)
+			}
+		}
+	}
+	return &result
+}()
+
+// newZobristKey works out the key of a card. Keys come from the same
+// splitmix64 generator as deals, so hashes saved by one release match the
+// next.
+func newZobristKey(pileNum int, pileIndex int, card poc.PositionedCard) poc.Hash {
+	source := dealSource{state: uint64(pileNum)<<40 | uint64(pileIndex)<<24 |
+		uint64(card.Card.Suit)<<16 | uint64(card.Card.Index)<<8 | uint64(card.Position)}
+	return poc.Hash(source.next())
+}
+
+// zobristKey is the random number XORed into a position's hash for card
+// sitting at pileIndex of pileNum.
+func zobristKey(pileNum int, pileIndex int, card poc.PositionedCard) poc.Hash {
+	if pileNum < zobristPiles && pileIndex < zobristIndexes &&
+		card.Card.Suit < 8 && card.Card.Index < 16 && card.Position&^poc.FaceUp == 0 {
+		slot := int(card.Card.Suit)<<5 | int(card.Card.Index)<<1 | int(card.Position)
+		return zobristKeys[pileNum][pileIndex][slot]
+	}
+	return newZobristKey(pileNum, pileIndex, card)
+}
+
+// pileHash is the part of a position's hash made by one pile.
+func pileHash(pileNum int, pile []poc.PositionedCard) poc.Hash {
+	var result poc.Hash
+	for pileIndex, card := range pile {
+		result ^= zobristKey(pileNum, pileIndex, card)
+	}
+	return result
+}
+
+// Hash is the Zobrist hash of a board's position, whatever game it comes
+// from. Score isn't part of the position.
+func Hash(board poc.Board) poc.Hash {
+	var result poc.Hash
+	for pileNum, pile := range board.Piles {
+		result ^= pileHash(pileNum, pile)
+	}
+	return result
+}
+
+// rehash updates the hash of the position before moves were applied to the
+// hash of the position after, looking only at the piles the moves touched.
+func rehash(hash poc.Hash, before [][]poc.PositionedCard, after [][]poc.PositionedCard, moves []poc.Move) poc.Hash {
+	for pileNum := range affected(moves) {
+		hash ^= pileHash(pileNum, before[pileNum]) ^ pileHash(pileNum, after[pileNum])
+	}
+	return hash
+}
+
+// affected lists the piles touched by a group of moves.
+func affected(moves []poc.Move) map[int]bool {
+	result := make(map[int]bool)
+	for _, m := range moves {
+		result[m.OldPileNum] = true
+		result[m.NewPileNum] = true
+	}
+	return result
+}
+
+// lastHash is the hash of the current position. It is worked out from the
+// board when the game has no hash for it, as with games saved before positions
+// were hashed.
+func lastHash(game poc.SavedGameDetail) poc.Hash {
+	if len(game.Hashes) == len(game.History) && len(game.Hashes) > 0 && game.Hashes[len(game.Hashes)-1] != 0 {
+		return game.Hashes[len(game.Hashes)-1]
+	}
+	return Hash(game.Board)
+}
+
+// nextHashes appends the hash of the position after moves to the game's
+// hashes. Missing hashes are left as 0.
+func nextHashes(game poc.SavedGameDetail, piles [][]poc.PositionedCard, moves []poc.Move) []poc.Hash {
+	hash := rehash(lastHash(game), game.Board.Piles, piles, moves)
+	result := make([]poc.Hash, len(game.History), len(game.History)+1)
+	copy(result, game.Hashes)
+	return append(result, hash)
+}
+
+// dealtHash is the hash of the position the game was dealt in, worked out by
+// taking back every move group from the current board.
+func dealtHash(game poc.SavedGameDetail) (poc.Hash, bool) {
+	hash := lastHash(game)
+	piles := game.Board.Piles
+	for i := len(game.History) - 1; i >= 0; i-- {
+		back := reverse(game.History[i])
+		before, err := apply(piles, back)
+		if err != nil {
+			return 0, false
+		}
+		hash = rehash(hash, piles, before, back)
+		piles = before
+	}
+	return hash, true
+}
+
+// looping reports whether a position has come up twice in the game, counting
+// the deal, as when cards are cycled through an unlimited stock. Hashes of 0
+// are unknown and never match.
+func looping(game poc.SavedGameDetail) bool {
+	seen := make(map[poc.Hash]bool, len(game.Hashes)+1)
+	if len(game.History) > 0 {
+		if dealt, ok := dealtHash(game); ok {
+			seen[dealt] = true
+		}
+	}
+	for _, hash := range game.Hashes {
+		if hash == 0 {
+			continue
+		}
+		if seen[hash] {
+			return true
+		}
+		seen[hash] = true
+	}
+	return false
+}
+
+// Transpositions is a transposition table for solvers and bots. It keeps a
+// value for each position it has seen, keyed by the position's Hash.
+type Transpositions map[poc.Hash]int32
@@ -0,0 +1,117 @@
+package rules_test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/slcjordan/poc"
+	"github.com/slcjordan/poc/pipeline"
+	"github.com/slcjordan/poc/rules"
+)
+
+// TestHashes plays random games and checks that the hash kept up move by move
+// matches hashing the board from scratch, and that undo and redo keep it.
+func TestHashes(t *testing.T) {
+	ctx := context.Background()
+	for seed := int64(1); seed <= 5; seed++ {
+		random := rand.New(rand.NewSource(seed))
+		start, err := pipeline.StartGame{rules.Shuffle{random}, rules.NextMove{}}.CallStartGame(ctx, poc.StartGame{Variant: poc.Variant{AllowUndo: true}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		game := start.SavedGameDetail
+		for step := 0; step < 60 && len(game.PossibleNextMoves) > 0; step++ {
+			next := game.PossibleNextMoves[random.Intn(len(game.PossibleNextMoves))]
+			move, err := pipeline.PerformMove{rules.Apply{}, rules.NextMove{}}.CallPerformMove(ctx, poc.PerformMove{SavedGameDetail: game, Next: next})
+			if err != nil {
+				t.Fatal(err)
+			}
+			prev := game
+			game = move.SavedGameDetail
+			if len(game.Hashes) != len(game.History) {
+				t.Fatalf("seed %d step %d: %d hashes for %d move groups", seed, step, len(game.Hashes), len(game.History))
+			}
+			hash := game.Hashes[len(game.Hashes)-1]
+			if want := rules.Hash(game.Board); hash != want {
+				t.Fatalf("seed %d step %d: expected hash %x but got %x", seed, step, want, hash)
+			}
+
+			undone, err := rules.Undo{}.CallUndoMove(ctx, poc.UndoMove{SavedGameDetail: game})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(undone.SavedGameDetail.Hashes) != len(prev.Hashes) || rules.Hash(undone.SavedGameDetail.Board) != rules.Hash(prev.Board) {
+				t.Fatalf("seed %d step %d: undo did not take back the position", seed, step)
+			}
+			redone, err := rules.Redo{}.CallRedoMove(ctx, poc.RedoMove{SavedGameDetail: undone.SavedGameDetail})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := redone.SavedGameDetail.Hashes; len(got) != len(game.Hashes) || got[len(got)-1] != hash {
+				t.Fatalf("seed %d step %d: redo gave hashes %x but expected %x", seed, step, got, game.Hashes)
+			}
+		}
+	}
+}
+
+func TestLooping(t *testing.T) {
+	ctx := context.Background()
+	var game poc.SavedGameDetail
+	game.Board = klondike()
+	game.Board.Piles[0] = []poc.PositionedCard{
+		{Card: poc.Card{Suit: poc.Spades, Index: poc.Five}},
+		{Card: poc.Card{Suit: poc.Clubs, Index: poc.Nine}},
+	}
+	game.Board.Piles[2] = []poc.PositionedCard{{Card: poc.Card{Suit: poc.Hearts, Index: poc.Ace}}}
+
+	// draw, draw and turn the talon over: nothing but the stock has moved, so
+	// the game is back where it was dealt.
+	stock := func(game poc.SavedGameDetail) []poc.Move {
+		for _, moves := range game.PossibleNextMoves {
+			if moves[0].OldPileNum < 2 && moves[0].NewPileNum < 2 {
+				return moves
+			}
+		}
+		t.Fatalf("no stock move in %v", game.PossibleNextMoves)
+		return nil
+	}
+	next, err := rules.NextMove{}.CallLookupGame(ctx, poc.LookupGame{SavedGameDetail: game})
+	if err != nil {
+		t.Fatal(err)
+	}
+	game = next.SavedGameDetail
+	for step := 0; step < 3; step++ {
+		if game.Looping {
+			t.Fatalf("step %d: game was looping too soon", step)
+		}
+		move, err := pipeline.PerformMove{rules.Apply{}, rules.NextMove{}}.CallPerformMove(ctx, poc.PerformMove{SavedGameDetail: game, Next: stock(game)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		game = move.SavedGameDetail
+	}
+	if !game.Looping {
+		t.Errorf("expected the game to be looping after %d stock moves", len(game.History))
+	}
+}
+
+// TestHashesStay checks that deals hash the same as they always have, so that
+// the hashes of saved games still match.
+func TestHashesStay(t *testing.T) {
+	for _, tc := range []struct {
+		variant poc.Variant
+		want    poc.Hash
+	}{
+		{poc.Variant{}, 0xddd7102ebcc6e22c},
+		{poc.Variant{Family: poc.Spider, Suits: 1}, 0x7f79393fdc73aad6}, // the stock is longer than the key table
+	} {
+		start, err := rules.Shuffle{rand.New(rand.NewSource(1))}.CallStartGame(context.Background(), poc.StartGame{Variant: tc.variant, Seed: 7})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := rules.Hash(start.SavedGameDetail.Board); got != tc.want {
+			t.Errorf("%s: expected hash %x but got %x", tc.variant.Family, tc.want, got)
+		}
+	}
+}
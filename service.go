@@ -8,18 +8,40 @@ type SavedGameSummary struct {
 	Score  int32
 }
 
-// SavedGameDetail is a saved game with detail of the game state.
+// SavedGameDetail is a saved game with detail of the game state. Hashes holds
+// the hash of the position after each History group, or 0 where it isn't
+// known. Looping is set when a position has come up more than once.
 type SavedGameDetail struct {
 	GameID            int64
 	Board             Board
 	History           [][]Move
+	Hashes            []Hash
 	PossibleNextMoves [][]Move
+	Redo              [][]Move
 	Variant           Variant
+	Status            GameStatus
+	Looping           bool
+	HintsUsed         int32
+	Seed              int64
+	Salt              string
+	Commitment        string
+	PreviousGameID    int64
 }
 
-// StartGame starts a game.
+// Revealed reports whether the deck order may be shown. Won and resigned
+// games can no longer be helped by it; stuck games can still be undone.
+func (s SavedGameDetail) Revealed() bool {
+	return s.Status == Won || s.Status == Resigned
+}
+
+// StartGame starts a game. A Seed of 0 deals a random game. A
+// SavedGameDetail.PreviousGameID carries the Vegas balance of that finished
+// game into this one; Previous is filled in from it before the deal.
 type StartGame struct {
 	Variant         Variant
+	WinnableOnly    bool
+	Seed            int64
+	Previous        SavedGameDetail
 	SavedGameDetail SavedGameDetail
 }
 
@@ -29,6 +51,81 @@ type PerformMove struct {
 	SavedGameDetail SavedGameDetail
 }
 
+// LookupGame fetches a single game.
+type LookupGame struct {
+	SavedGameDetail SavedGameDetail
+}
+
+// ResignGame gives up on a game.
+type ResignGame struct {
+	SavedGameDetail SavedGameDetail
+}
+
+// AutoComplete finishes a game by moving every card to the foundations.
+type AutoComplete struct {
+	Moves           [][]Move
+	SavedGameDetail SavedGameDetail
+}
+
+// SolveGame searches for a way to win a game. MaxNodes may lower the number
+// of positions the solver is allowed to search.
+type SolveGame struct {
+	MaxNodes        int32
+	Solvability     Solvability
+	NodesSearched   int32
+	Solution        [][]Move
+	SavedGameDetail SavedGameDetail
+}
+
+// RankedMove is a move group with a heuristic score. Higher scores are better.
+type RankedMove struct {
+	Moves  []Move
+	Score  int32
+	Reason HintReason
+}
+
+// Hint ranks the possible next moves, best first.
+type Hint struct {
+	Moves           []RankedMove
+	SavedGameDetail SavedGameDetail
+}
+
+// Fairness proves that a game was dealt from the deck the server committed
+// to when the game started. The salt and deck are only revealed once the game
+// is over.
+type Fairness struct {
+	Revealed        bool
+	Deck            []Card
+	SavedGameDetail SavedGameDetail
+}
+
+// Replay rebuilds the board of a game as it was after its first MoveNumber
+// move groups, starting from the dealt board. Boards holds the dealt board and
+// every board after it when Stream is set. If a move group can't be made on
+// the board before it, Inconsistent is its move number, Problem says why and
+// Board is the last board that could be rebuilt. Status is the status of
+// Board.
+type Replay struct {
+	MoveNumber      int32
+	Stream          bool
+	Board           Board
+	Boards          []Board
+	Status          GameStatus
+	Inconsistent    int32
+	Problem         string
+	SavedGameDetail SavedGameDetail
+}
+
+// UndoMove takes back the last move group in the history.
+type UndoMove struct {
+	SavedGameDetail SavedGameDetail
+}
+
+// RedoMove plays the last undone move group again.
+type RedoMove struct {
+	SavedGameDetail SavedGameDetail
+}
+
 // ListGames lists running games.
 type ListGames struct {
 	Cursor struct {
@@ -11,10 +11,19 @@ import (
 )
 
 type Assertion struct {
-	PerformMove PerformMove
-	StartGame   StartGame
-	ListGames   ListGames
-	Error       Error
+	PerformMove  PerformMove
+	StartGame    StartGame
+	ListGames    ListGames
+	LookupGame   LookupGame
+	Fairness     Fairness
+	Replay       Replay
+	RedoMove     RedoMove
+	UndoMove     UndoMove
+	Hint         Hint
+	SolveGame    SolveGame
+	AutoComplete AutoComplete
+	ResignGame   ResignGame
+	Error        Error
 
 	noError bool
 }
@@ -22,6 +31,15 @@ type Assertion struct {
 func New() *Assertion {
 	var assertion Assertion
 	assertion.ListGames = newListGames(&assertion)
+	assertion.LookupGame = newLookupGame(&assertion)
+	assertion.Fairness = newFairness(&assertion)
+	assertion.Replay = newReplay(&assertion)
+	assertion.RedoMove = newRedoMove(&assertion)
+	assertion.UndoMove = newUndoMove(&assertion)
+	assertion.Hint = newHint(&assertion)
+	assertion.SolveGame = newSolveGame(&assertion)
+	assertion.AutoComplete = newAutoComplete(&assertion)
+	assertion.ResignGame = newResignGame(&assertion)
 	assertion.PerformMove = newPerformMove(&assertion)
 	assertion.StartGame = newStartGame(&assertion)
 	assertion.Error = newError(&assertion)
@@ -32,6 +50,42 @@ func (a *Assertion) CheckListGames(t *testing.T, desc string, val poc.ListGames)
 	a.ListGames.CheckListGames(t, desc+"ListGames", val)
 }
 
+func (a *Assertion) CheckLookupGame(t *testing.T, desc string, val poc.LookupGame) {
+	a.LookupGame.CheckLookupGame(t, desc+"LookupGame", val)
+}
+
+func (a *Assertion) CheckFairness(t *testing.T, desc string, val poc.Fairness) {
+	a.Fairness.CheckFairness(t, desc+"Fairness", val)
+}
+
+func (a *Assertion) CheckReplay(t *testing.T, desc string, val poc.Replay) {
+	a.Replay.CheckReplay(t, desc+"Replay", val)
+}
+
+func (a *Assertion) CheckRedoMove(t *testing.T, desc string, val poc.RedoMove) {
+	a.RedoMove.CheckRedoMove(t, desc+"RedoMove", val)
+}
+
+func (a *Assertion) CheckUndoMove(t *testing.T, desc string, val poc.UndoMove) {
+	a.UndoMove.CheckUndoMove(t, desc+"UndoMove", val)
+}
+
+func (a *Assertion) CheckHint(t *testing.T, desc string, val poc.Hint) {
+	a.Hint.CheckHint(t, desc+"Hint", val)
+}
+
+func (a *Assertion) CheckSolveGame(t *testing.T, desc string, val poc.SolveGame) {
+	a.SolveGame.CheckSolveGame(t, desc+"SolveGame", val)
+}
+
+func (a *Assertion) CheckAutoComplete(t *testing.T, desc string, val poc.AutoComplete) {
+	a.AutoComplete.CheckAutoComplete(t, desc+"AutoComplete", val)
+}
+
+func (a *Assertion) CheckResignGame(t *testing.T, desc string, val poc.ResignGame) {
+	a.ResignGame.CheckResignGame(t, desc+"ResignGame", val)
+}
+
 func (a *Assertion) CheckPerformMove(t *testing.T, desc string, val poc.PerformMove) {
 	a.PerformMove.CheckPerformMove(t, desc+"PerformMove", val)
 }
@@ -104,3 +158,25 @@ func (e Equals) CheckInt64(t *testing.T, desc string, val int64) {
 		}
 	})
 }
+
+type EqualsBool bool
+
+func (e EqualsBool) CheckBool(t *testing.T, desc string, val bool) {
+	t.Run(fmt.Sprintf("%s equals %t", desc, e), func(t *testing.T) {
+		expected := bool(e)
+		if val != expected {
+			t.Errorf("expected %t but got %t", expected, val)
+		}
+	})
+}
+
+type EqualsString string
+
+func (e EqualsString) CheckString(t *testing.T, desc string, val string) {
+	t.Run(fmt.Sprintf("%s equals %q", desc, e), func(t *testing.T) {
+		expected := string(e)
+		if val != expected {
+			t.Errorf("expected %q but got %q", expected, val)
+		}
+	})
+}
@@ -13,13 +13,17 @@ type Board struct {
 	assertion     *Assertion
 	scoreCheckers []Int32Checker
 
-	Piles PositionedCardArray2D
+	Covers CoverArray1D
+	Piles  PositionedCardArray2D
+	Slots  SlotArray1D
 }
 
 func newBoard(assertion *Assertion) Board {
 	return Board{
 		assertion: assertion,
+		Covers:    newCoverArray1D(assertion),
 		Piles:     newPositionedCardArray2D(assertion),
+		Slots:     newSlotArray1D(assertion),
 	}
 }
 
@@ -32,7 +36,9 @@ func (parent *Board) CheckBoard(t *testing.T, desc string, val poc.Board) {
 	for _, checker := range parent.scoreCheckers {
 		checker.CheckInt32(t, desc+".Score", val.Score)
 	}
+	parent.Covers.CheckCoverArray1D(t, desc+".Covers", val.Covers)
 	parent.Piles.CheckPositionedCardArray2D(t, desc+".Piles", val.Piles)
+	parent.Slots.CheckSlotArray1D(t, desc+".Slots", val.Slots)
 }
 
 type Card struct {
@@ -55,6 +61,147 @@ func (parent *Card) CheckCard(t *testing.T, desc string, val poc.Card) {
 	parent.Suit.CheckSuit(t, desc+".Suit", val.Suit)
 }
 
+type ColumnFill struct {
+	assertion     *Assertion
+	uint8Checkers []Uint8Checker
+}
+
+func newColumnFill(assertion *Assertion) ColumnFill {
+	return ColumnFill{
+		assertion: assertion,
+	}
+}
+
+func (parent *ColumnFill) Uint8(checkers ...Uint8Checker) *Assertion {
+	parent.uint8Checkers = checkers
+	return parent.assertion
+}
+
+func (parent *ColumnFill) CheckColumnFill(t *testing.T, desc string, val poc.ColumnFill) {
+	for _, checker := range parent.uint8Checkers {
+		checker.CheckUint8(t, desc+".uint8", uint8(val))
+	}
+}
+
+type Cover struct {
+	assertion         *Assertion
+	coveredByCheckers []IntChecker
+	pileNumCheckers   []IntChecker
+}
+
+func newCover(assertion *Assertion) Cover {
+	return Cover{
+		assertion: assertion,
+	}
+}
+
+func (parent *Cover) CoveredBy(checkers ...IntChecker) *Assertion {
+	parent.coveredByCheckers = checkers
+	return parent.assertion
+}
+
+func (parent *Cover) PileNum(checkers ...IntChecker) *Assertion {
+	parent.pileNumCheckers = checkers
+	return parent.assertion
+}
+
+func (parent *Cover) CheckCover(t *testing.T, desc string, val poc.Cover) {
+	for _, checker := range parent.coveredByCheckers {
+		checker.CheckInt(t, desc+".CoveredBy", val.CoveredBy)
+	}
+	for _, checker := range parent.pileNumCheckers {
+		checker.CheckInt(t, desc+".PileNum", val.PileNum)
+	}
+}
+
+type Family struct {
+	assertion     *Assertion
+	uint8Checkers []Uint8Checker
+}
+
+func newFamily(assertion *Assertion) Family {
+	return Family{
+		assertion: assertion,
+	}
+}
+
+func (parent *Family) Uint8(checkers ...Uint8Checker) *Assertion {
+	parent.uint8Checkers = checkers
+	return parent.assertion
+}
+
+func (parent *Family) CheckFamily(t *testing.T, desc string, val poc.Family) {
+	for _, checker := range parent.uint8Checkers {
+		checker.CheckUint8(t, desc+".uint8", uint8(val))
+	}
+}
+
+type GameStatus struct {
+	assertion     *Assertion
+	uint8Checkers []Uint8Checker
+}
+
+func newGameStatus(assertion *Assertion) GameStatus {
+	return GameStatus{
+		assertion: assertion,
+	}
+}
+
+func (parent *GameStatus) Uint8(checkers ...Uint8Checker) *Assertion {
+	parent.uint8Checkers = checkers
+	return parent.assertion
+}
+
+func (parent *GameStatus) CheckGameStatus(t *testing.T, desc string, val poc.GameStatus) {
+	for _, checker := range parent.uint8Checkers {
+		checker.CheckUint8(t, desc+".uint8", uint8(val))
+	}
+}
+
+type Hash struct {
+	assertion      *Assertion
+	uint64Checkers []Uint64Checker
+}
+
+func newHash(assertion *Assertion) Hash {
+	return Hash{
+		assertion: assertion,
+	}
+}
+
+func (parent *Hash) Uint64(checkers ...Uint64Checker) *Assertion {
+	parent.uint64Checkers = checkers
+	return parent.assertion
+}
+
+func (parent *Hash) CheckHash(t *testing.T, desc string, val poc.Hash) {
+	for _, checker := range parent.uint64Checkers {
+		checker.CheckUint64(t, desc+".uint64", uint64(val))
+	}
+}
+
+type HintReason struct {
+	assertion     *Assertion
+	uint8Checkers []Uint8Checker
+}
+
+func newHintReason(assertion *Assertion) HintReason {
+	return HintReason{
+		assertion: assertion,
+	}
+}
+
+func (parent *HintReason) Uint8(checkers ...Uint8Checker) *Assertion {
+	parent.uint8Checkers = checkers
+	return parent.assertion
+}
+
+func (parent *HintReason) CheckHintReason(t *testing.T, desc string, val poc.HintReason) {
+	for _, checker := range parent.uint8Checkers {
+		checker.CheckUint8(t, desc+".uint8", uint8(val))
+	}
+}
+
 type Index struct {
 	assertion     *Assertion
 	uint8Checkers []Uint8Checker
@@ -175,6 +322,90 @@ func (parent *PositionedCard) CheckPositionedCard(t *testing.T, desc string, val
 	parent.Position.CheckPosition(t, desc+".Position", val.Position)
 }
 
+type Scoring struct {
+	assertion     *Assertion
+	uint8Checkers []Uint8Checker
+}
+
+func newScoring(assertion *Assertion) Scoring {
+	return Scoring{
+		assertion: assertion,
+	}
+}
+
+func (parent *Scoring) Uint8(checkers ...Uint8Checker) *Assertion {
+	parent.uint8Checkers = checkers
+	return parent.assertion
+}
+
+func (parent *Scoring) CheckScoring(t *testing.T, desc string, val poc.Scoring) {
+	for _, checker := range parent.uint8Checkers {
+		checker.CheckUint8(t, desc+".uint8", uint8(val))
+	}
+}
+
+type Slot struct {
+	assertion       *Assertion
+	columnCheckers  []IntChecker
+	pileNumCheckers []IntChecker
+	rowCheckers     []IntChecker
+}
+
+func newSlot(assertion *Assertion) Slot {
+	return Slot{
+		assertion: assertion,
+	}
+}
+
+func (parent *Slot) Column(checkers ...IntChecker) *Assertion {
+	parent.columnCheckers = checkers
+	return parent.assertion
+}
+
+func (parent *Slot) PileNum(checkers ...IntChecker) *Assertion {
+	parent.pileNumCheckers = checkers
+	return parent.assertion
+}
+
+func (parent *Slot) Row(checkers ...IntChecker) *Assertion {
+	parent.rowCheckers = checkers
+	return parent.assertion
+}
+
+func (parent *Slot) CheckSlot(t *testing.T, desc string, val poc.Slot) {
+	for _, checker := range parent.columnCheckers {
+		checker.CheckInt(t, desc+".Column", val.Column)
+	}
+	for _, checker := range parent.pileNumCheckers {
+		checker.CheckInt(t, desc+".PileNum", val.PileNum)
+	}
+	for _, checker := range parent.rowCheckers {
+		checker.CheckInt(t, desc+".Row", val.Row)
+	}
+}
+
+type Solvability struct {
+	assertion     *Assertion
+	uint8Checkers []Uint8Checker
+}
+
+func newSolvability(assertion *Assertion) Solvability {
+	return Solvability{
+		assertion: assertion,
+	}
+}
+
+func (parent *Solvability) Uint8(checkers ...Uint8Checker) *Assertion {
+	parent.uint8Checkers = checkers
+	return parent.assertion
+}
+
+func (parent *Solvability) CheckSolvability(t *testing.T, desc string, val poc.Solvability) {
+	for _, checker := range parent.uint8Checkers {
+		checker.CheckUint8(t, desc+".uint8", uint8(val))
+	}
+}
+
 type Suit struct {
 	assertion     *Assertion
 	uint8Checkers []Uint8Checker
@@ -199,30 +430,146 @@ func (parent *Suit) CheckSuit(t *testing.T, desc string, val poc.Suit) {
 
 type Variant struct {
 	assertion                   *Assertion
+	allowUndoCheckers           []BoolChecker
+	drawCountCheckers           []Int32Checker
+	hintPenaltyCheckers         []Int32Checker
+	jokersCheckers              []Int32Checker
 	maxTimesThroughDeckCheckers []Int32Checker
+	nameCheckers                []StringChecker
+	suitsCheckers               []Int32Checker
+	undoPenaltyCheckers         []Int32Checker
+
+	EmptyColumnFill ColumnFill
+	Family          Family
+	Scoring         Scoring
 }
 
 func newVariant(assertion *Assertion) Variant {
 	return Variant{
-		assertion: assertion,
+		assertion:       assertion,
+		EmptyColumnFill: newColumnFill(assertion),
+		Family:          newFamily(assertion),
+		Scoring:         newScoring(assertion),
 	}
 }
 
+func (parent *Variant) AllowUndo(checkers ...BoolChecker) *Assertion {
+	parent.allowUndoCheckers = checkers
+	return parent.assertion
+}
+
+func (parent *Variant) DrawCount(checkers ...Int32Checker) *Assertion {
+	parent.drawCountCheckers = checkers
+	return parent.assertion
+}
+
+func (parent *Variant) HintPenalty(checkers ...Int32Checker) *Assertion {
+	parent.hintPenaltyCheckers = checkers
+	return parent.assertion
+}
+
+func (parent *Variant) Jokers(checkers ...Int32Checker) *Assertion {
+	parent.jokersCheckers = checkers
+	return parent.assertion
+}
+
 func (parent *Variant) MaxTimesThroughDeck(checkers ...Int32Checker) *Assertion {
 	parent.maxTimesThroughDeckCheckers = checkers
 	return parent.assertion
 }
 
+func (parent *Variant) Name(checkers ...StringChecker) *Assertion {
+	parent.nameCheckers = checkers
+	return parent.assertion
+}
+
+func (parent *Variant) Suits(checkers ...Int32Checker) *Assertion {
+	parent.suitsCheckers = checkers
+	return parent.assertion
+}
+
+func (parent *Variant) UndoPenalty(checkers ...Int32Checker) *Assertion {
+	parent.undoPenaltyCheckers = checkers
+	return parent.assertion
+}
+
 func (parent *Variant) CheckVariant(t *testing.T, desc string, val poc.Variant) {
+	for _, checker := range parent.allowUndoCheckers {
+		checker.CheckBool(t, desc+".AllowUndo", val.AllowUndo)
+	}
+	for _, checker := range parent.drawCountCheckers {
+		checker.CheckInt32(t, desc+".DrawCount", val.DrawCount)
+	}
+	for _, checker := range parent.hintPenaltyCheckers {
+		checker.CheckInt32(t, desc+".HintPenalty", val.HintPenalty)
+	}
+	for _, checker := range parent.jokersCheckers {
+		checker.CheckInt32(t, desc+".Jokers", val.Jokers)
+	}
 	for _, checker := range parent.maxTimesThroughDeckCheckers {
 		checker.CheckInt32(t, desc+".MaxTimesThroughDeck", val.MaxTimesThroughDeck)
 	}
+	for _, checker := range parent.nameCheckers {
+		checker.CheckString(t, desc+".Name", val.Name)
+	}
+	for _, checker := range parent.suitsCheckers {
+		checker.CheckInt32(t, desc+".Suits", val.Suits)
+	}
+	for _, checker := range parent.undoPenaltyCheckers {
+		checker.CheckInt32(t, desc+".UndoPenalty", val.UndoPenalty)
+	}
+	parent.EmptyColumnFill.CheckColumnFill(t, desc+".EmptyColumnFill", val.EmptyColumnFill)
+	parent.Family.CheckFamily(t, desc+".Family", val.Family)
+	parent.Scoring.CheckScoring(t, desc+".Scoring", val.Scoring)
+}
+
+type CoverArray1D struct {
+	assertion      *Assertion
+	lengthCheckers []IntChecker
+	nth            map[int]*Cover
+
+	ForEach Cover
+}
+
+func newCoverArray1D(assertion *Assertion) CoverArray1D {
+	return CoverArray1D{
+		assertion: assertion,
+		nth:       make(map[int]*Cover),
+		ForEach:   newCover(assertion),
+	}
+}
+
+func (a *CoverArray1D) Nth(i int) *Cover {
+	prev, ok := a.nth[i]
+	if ok {
+		return prev
+	}
+	result := newCover(a.assertion)
+	a.nth[i] = &result
+	return &result
+}
+
+func (a *CoverArray1D) Length(checkers ...IntChecker) *Assertion {
+	a.lengthCheckers = checkers
+	return a.assertion
+}
+
+func (a *CoverArray1D) CheckCoverArray1D(t *testing.T, desc string, val []poc.Cover) {
+	for _, checker := range a.lengthCheckers {
+		checker.CheckInt(t, desc+".length", len(val))
+	}
+	for i, checker := range a.nth {
+		checker.CheckCover(t, desc+fmt.Sprintf("[%d]", i), val[i])
+	}
+	for _, curr := range val {
+		a.ForEach.CheckCover(t, desc+".ForEach", curr)
+	}
 }
 
 type PositionedCardArray1D struct {
 	assertion      *Assertion
 	lengthCheckers []IntChecker
-	nth            map[int]PositionedCard
+	nth            map[int]*PositionedCard
 
 	ForEach PositionedCard
 }
@@ -230,19 +577,19 @@ type PositionedCardArray1D struct {
 func newPositionedCardArray1D(assertion *Assertion) PositionedCardArray1D {
 	return PositionedCardArray1D{
 		assertion: assertion,
-		nth:       make(map[int]PositionedCard),
+		nth:       make(map[int]*PositionedCard),
 		ForEach:   newPositionedCard(assertion),
 	}
 }
 
-func (a *PositionedCardArray1D) Nth(i int) PositionedCard {
+func (a *PositionedCardArray1D) Nth(i int) *PositionedCard {
 	prev, ok := a.nth[i]
 	if ok {
 		return prev
 	}
 	result := newPositionedCard(a.assertion)
-	a.nth[i] = result
-	return result
+	a.nth[i] = &result
+	return &result
 }
 
 func (a *PositionedCardArray1D) Length(checkers ...IntChecker) *Assertion {
@@ -265,7 +612,7 @@ func (a *PositionedCardArray1D) CheckPositionedCardArray1D(t *testing.T, desc st
 type PositionedCardArray2D struct {
 	assertion      *Assertion
 	lengthCheckers []IntChecker
-	nth            map[int]PositionedCardArray1D
+	nth            map[int]*PositionedCardArray1D
 
 	ForEach PositionedCardArray1D
 }
@@ -273,19 +620,19 @@ type PositionedCardArray2D struct {
 func newPositionedCardArray2D(assertion *Assertion) PositionedCardArray2D {
 	return PositionedCardArray2D{
 		assertion: assertion,
-		nth:       make(map[int]PositionedCardArray1D),
+		nth:       make(map[int]*PositionedCardArray1D),
 		ForEach:   newPositionedCardArray1D(assertion),
 	}
 }
 
-func (a *PositionedCardArray2D) Nth(i int) PositionedCardArray1D {
+func (a *PositionedCardArray2D) Nth(i int) *PositionedCardArray1D {
 	prev, ok := a.nth[i]
 	if ok {
 		return prev
 	}
 	result := newPositionedCardArray1D(a.assertion)
-	a.nth[i] = result
-	return result
+	a.nth[i] = &result
+	return &result
 }
 
 func (a *PositionedCardArray2D) Length(checkers ...IntChecker) *Assertion {
@@ -293,7 +640,7 @@ func (a *PositionedCardArray2D) Length(checkers ...IntChecker) *Assertion {
 	return a.assertion
 }
 
-func (a *PositionedCardArray2D) CheckPositionedCardArray2D(t *testing.T, desc string, val [13][]poc.PositionedCard) {
+func (a *PositionedCardArray2D) CheckPositionedCardArray2D(t *testing.T, desc string, val [][]poc.PositionedCard) {
 	for _, checker := range a.lengthCheckers {
 		checker.CheckInt(t, desc+".length", len(val))
 	}
@@ -304,3 +651,46 @@ func (a *PositionedCardArray2D) CheckPositionedCardArray2D(t *testing.T, desc st
 		a.ForEach.CheckPositionedCardArray1D(t, desc+".ForEach", curr)
 	}
 }
+
+type SlotArray1D struct {
+	assertion      *Assertion
+	lengthCheckers []IntChecker
+	nth            map[int]*Slot
+
+	ForEach Slot
+}
+
+func newSlotArray1D(assertion *Assertion) SlotArray1D {
+	return SlotArray1D{
+		assertion: assertion,
+		nth:       make(map[int]*Slot),
+		ForEach:   newSlot(assertion),
+	}
+}
+
+func (a *SlotArray1D) Nth(i int) *Slot {
+	prev, ok := a.nth[i]
+	if ok {
+		return prev
+	}
+	result := newSlot(a.assertion)
+	a.nth[i] = &result
+	return &result
+}
+
+func (a *SlotArray1D) Length(checkers ...IntChecker) *Assertion {
+	a.lengthCheckers = checkers
+	return a.assertion
+}
+
+func (a *SlotArray1D) CheckSlotArray1D(t *testing.T, desc string, val []poc.Slot) {
+	for _, checker := range a.lengthCheckers {
+		checker.CheckInt(t, desc+".length", len(val))
+	}
+	for i, checker := range a.nth {
+		checker.CheckSlot(t, desc+fmt.Sprintf("[%d]", i), val[i])
+	}
+	for _, curr := range val {
+		a.ForEach.CheckSlot(t, desc+".ForEach", curr)
+	}
+}
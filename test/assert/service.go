@@ -9,6 +9,75 @@ import (
 	"github.com/slcjordan/poc"
 )
 
+type AutoComplete struct {
+	assertion *Assertion
+
+	Moves           MoveArray2D
+	SavedGameDetail SavedGameDetail
+}
+
+func newAutoComplete(assertion *Assertion) AutoComplete {
+	return AutoComplete{
+		assertion:       assertion,
+		Moves:           newMoveArray2D(assertion),
+		SavedGameDetail: newSavedGameDetail(assertion),
+	}
+}
+
+func (parent *AutoComplete) CheckAutoComplete(t *testing.T, desc string, val poc.AutoComplete) {
+	parent.Moves.CheckMoveArray2D(t, desc+".Moves", val.Moves)
+	parent.SavedGameDetail.CheckSavedGameDetail(t, desc+".SavedGameDetail", val.SavedGameDetail)
+}
+
+type Fairness struct {
+	assertion        *Assertion
+	revealedCheckers []BoolChecker
+
+	Deck            CardArray1D
+	SavedGameDetail SavedGameDetail
+}
+
+func newFairness(assertion *Assertion) Fairness {
+	return Fairness{
+		assertion:       assertion,
+		Deck:            newCardArray1D(assertion),
+		SavedGameDetail: newSavedGameDetail(assertion),
+	}
+}
+
+func (parent *Fairness) Revealed(checkers ...BoolChecker) *Assertion {
+	parent.revealedCheckers = checkers
+	return parent.assertion
+}
+
+func (parent *Fairness) CheckFairness(t *testing.T, desc string, val poc.Fairness) {
+	for _, checker := range parent.revealedCheckers {
+		checker.CheckBool(t, desc+".Revealed", val.Revealed)
+	}
+	parent.Deck.CheckCardArray1D(t, desc+".Deck", val.Deck)
+	parent.SavedGameDetail.CheckSavedGameDetail(t, desc+".SavedGameDetail", val.SavedGameDetail)
+}
+
+type Hint struct {
+	assertion *Assertion
+
+	Moves           RankedMoveArray1D
+	SavedGameDetail SavedGameDetail
+}
+
+func newHint(assertion *Assertion) Hint {
+	return Hint{
+		assertion:       assertion,
+		Moves:           newRankedMoveArray1D(assertion),
+		SavedGameDetail: newSavedGameDetail(assertion),
+	}
+}
+
+func (parent *Hint) CheckHint(t *testing.T, desc string, val poc.Hint) {
+	parent.Moves.CheckRankedMoveArray1D(t, desc+".Moves", val.Moves)
+	parent.SavedGameDetail.CheckSavedGameDetail(t, desc+".SavedGameDetail", val.SavedGameDetail)
+}
+
 type ListGames struct {
 	assertion            *Assertion
 	cursorLimitCheckers  []Int32Checker
@@ -44,6 +113,23 @@ func (parent *ListGames) CheckListGames(t *testing.T, desc string, val poc.ListG
 	parent.Games.CheckSavedGameSummaryArray1D(t, desc+".Games", val.Games)
 }
 
+type LookupGame struct {
+	assertion *Assertion
+
+	SavedGameDetail SavedGameDetail
+}
+
+func newLookupGame(assertion *Assertion) LookupGame {
+	return LookupGame{
+		assertion:       assertion,
+		SavedGameDetail: newSavedGameDetail(assertion),
+	}
+}
+
+func (parent *LookupGame) CheckLookupGame(t *testing.T, desc string, val poc.LookupGame) {
+	parent.SavedGameDetail.CheckSavedGameDetail(t, desc+".SavedGameDetail", val.SavedGameDetail)
+}
+
 type PerformMove struct {
 	assertion *Assertion
 
@@ -64,13 +150,147 @@ func (parent *PerformMove) CheckPerformMove(t *testing.T, desc string, val poc.P
 	parent.SavedGameDetail.CheckSavedGameDetail(t, desc+".SavedGameDetail", val.SavedGameDetail)
 }
 
+type RankedMove struct {
+	assertion     *Assertion
+	scoreCheckers []Int32Checker
+
+	Moves  MoveArray1D
+	Reason HintReason
+}
+
+func newRankedMove(assertion *Assertion) RankedMove {
+	return RankedMove{
+		assertion: assertion,
+		Moves:     newMoveArray1D(assertion),
+		Reason:    newHintReason(assertion),
+	}
+}
+
+func (parent *RankedMove) Score(checkers ...Int32Checker) *Assertion {
+	parent.scoreCheckers = checkers
+	return parent.assertion
+}
+
+func (parent *RankedMove) CheckRankedMove(t *testing.T, desc string, val poc.RankedMove) {
+	for _, checker := range parent.scoreCheckers {
+		checker.CheckInt32(t, desc+".Score", val.Score)
+	}
+	parent.Moves.CheckMoveArray1D(t, desc+".Moves", val.Moves)
+	parent.Reason.CheckHintReason(t, desc+".Reason", val.Reason)
+}
+
+type RedoMove struct {
+	assertion *Assertion
+
+	SavedGameDetail SavedGameDetail
+}
+
+func newRedoMove(assertion *Assertion) RedoMove {
+	return RedoMove{
+		assertion:       assertion,
+		SavedGameDetail: newSavedGameDetail(assertion),
+	}
+}
+
+func (parent *RedoMove) CheckRedoMove(t *testing.T, desc string, val poc.RedoMove) {
+	parent.SavedGameDetail.CheckSavedGameDetail(t, desc+".SavedGameDetail", val.SavedGameDetail)
+}
+
+type Replay struct {
+	assertion            *Assertion
+	inconsistentCheckers []Int32Checker
+	moveNumberCheckers   []Int32Checker
+	problemCheckers      []StringChecker
+	streamCheckers       []BoolChecker
+
+	Board           Board
+	Boards          BoardArray1D
+	SavedGameDetail SavedGameDetail
+	Status          GameStatus
+}
+
+func newReplay(assertion *Assertion) Replay {
+	return Replay{
+		assertion:       assertion,
+		Board:           newBoard(assertion),
+		Boards:          newBoardArray1D(assertion),
+		SavedGameDetail: newSavedGameDetail(assertion),
+		Status:          newGameStatus(assertion),
+	}
+}
+
+func (parent *Replay) Inconsistent(checkers ...Int32Checker) *Assertion {
+	parent.inconsistentCheckers = checkers
+	return parent.assertion
+}
+
+func (parent *Replay) MoveNumber(checkers ...Int32Checker) *Assertion {
+	parent.moveNumberCheckers = checkers
+	return parent.assertion
+}
+
+func (parent *Replay) Problem(checkers ...StringChecker) *Assertion {
+	parent.problemCheckers = checkers
+	return parent.assertion
+}
+
+func (parent *Replay) Stream(checkers ...BoolChecker) *Assertion {
+	parent.streamCheckers = checkers
+	return parent.assertion
+}
+
+func (parent *Replay) CheckReplay(t *testing.T, desc string, val poc.Replay) {
+	for _, checker := range parent.inconsistentCheckers {
+		checker.CheckInt32(t, desc+".Inconsistent", val.Inconsistent)
+	}
+	for _, checker := range parent.moveNumberCheckers {
+		checker.CheckInt32(t, desc+".MoveNumber", val.MoveNumber)
+	}
+	for _, checker := range parent.problemCheckers {
+		checker.CheckString(t, desc+".Problem", val.Problem)
+	}
+	for _, checker := range parent.streamCheckers {
+		checker.CheckBool(t, desc+".Stream", val.Stream)
+	}
+	parent.Board.CheckBoard(t, desc+".Board", val.Board)
+	parent.Boards.CheckBoardArray1D(t, desc+".Boards", val.Boards)
+	parent.SavedGameDetail.CheckSavedGameDetail(t, desc+".SavedGameDetail", val.SavedGameDetail)
+	parent.Status.CheckGameStatus(t, desc+".Status", val.Status)
+}
+
+type ResignGame struct {
+	assertion *Assertion
+
+	SavedGameDetail SavedGameDetail
+}
+
+func newResignGame(assertion *Assertion) ResignGame {
+	return ResignGame{
+		assertion:       assertion,
+		SavedGameDetail: newSavedGameDetail(assertion),
+	}
+}
+
+func (parent *ResignGame) CheckResignGame(t *testing.T, desc string, val poc.ResignGame) {
+	parent.SavedGameDetail.CheckSavedGameDetail(t, desc+".SavedGameDetail", val.SavedGameDetail)
+}
+
 type SavedGameDetail struct {
-	assertion      *Assertion
-	gameIDCheckers []Int64Checker
+	assertion              *Assertion
+	commitmentCheckers     []StringChecker
+	gameIDCheckers         []Int64Checker
+	hintsUsedCheckers      []Int32Checker
+	loopingCheckers        []BoolChecker
+	previousGameIDCheckers []Int64Checker
+	saltCheckers           []StringChecker
+	seedCheckers           []Int64Checker
 
 	Board             Board
+	Hashes            HashArray1D
 	History           MoveArray2D
 	PossibleNextMoves MoveArray2D
+	Redo              MoveArray2D
+	Status            GameStatus
 	Variant           Variant
 }
 
@@ -78,24 +298,78 @@ func newSavedGameDetail(assertion *Assertion) SavedGameDetail {
 	return SavedGameDetail{
 		assertion:         assertion,
 		Board:             newBoard(assertion),
+		Hashes:            newHashArray1D(assertion),
 		History:           newMoveArray2D(assertion),
 		PossibleNextMoves: newMoveArray2D(assertion),
+		Redo:              newMoveArray2D(assertion),
+		Status:            newGameStatus(assertion),
 		Variant:           newVariant(assertion),
 	}
 }
 
+func (parent *SavedGameDetail) Commitment(checkers ...StringChecker) *Assertion {
+	parent.commitmentCheckers = checkers
+	return parent.assertion
+}
+
 func (parent *SavedGameDetail) GameID(checkers ...Int64Checker) *Assertion {
 	parent.gameIDCheckers = checkers
 	return parent.assertion
 }
 
+func (parent *SavedGameDetail) HintsUsed(checkers ...Int32Checker) *Assertion {
+	parent.hintsUsedCheckers = checkers
+	return parent.assertion
+}
+
+func (parent *SavedGameDetail) Looping(checkers ...BoolChecker) *Assertion {
+	parent.loopingCheckers = checkers
+	return parent.assertion
+}
+
+func (parent *SavedGameDetail) PreviousGameID(checkers ...Int64Checker) *Assertion {
+	parent.previousGameIDCheckers = checkers
+	return parent.assertion
+}
+
+func (parent *SavedGameDetail) Salt(checkers ...StringChecker) *Assertion {
+	parent.saltCheckers = checkers
+	return parent.assertion
+}
+
+func (parent *SavedGameDetail) Seed(checkers ...Int64Checker) *Assertion {
+	parent.seedCheckers = checkers
+	return parent.assertion
+}
+
 func (parent *SavedGameDetail) CheckSavedGameDetail(t *testing.T, desc string, val poc.SavedGameDetail) {
+	for _, checker := range parent.commitmentCheckers {
+		checker.CheckString(t, desc+".Commitment", val.Commitment)
+	}
 	for _, checker := range parent.gameIDCheckers {
 		checker.CheckInt64(t, desc+".GameID", val.GameID)
 	}
+	for _, checker := range parent.hintsUsedCheckers {
+		checker.CheckInt32(t, desc+".HintsUsed", val.HintsUsed)
+	}
+	for _, checker := range parent.loopingCheckers {
+		checker.CheckBool(t, desc+".Looping", val.Looping)
+	}
+	for _, checker := range parent.previousGameIDCheckers {
+		checker.CheckInt64(t, desc+".PreviousGameID", val.PreviousGameID)
+	}
+	for _, checker := range parent.saltCheckers {
+		checker.CheckString(t, desc+".Salt", val.Salt)
+	}
+	for _, checker := range parent.seedCheckers {
+		checker.CheckInt64(t, desc+".Seed", val.Seed)
+	}
 	parent.Board.CheckBoard(t, desc+".Board", val.Board)
+	parent.Hashes.CheckHashArray1D(t, desc+".Hashes", val.Hashes)
 	parent.History.CheckMoveArray2D(t, desc+".History", val.History)
 	parent.PossibleNextMoves.CheckMoveArray2D(t, desc+".PossibleNextMoves", val.PossibleNextMoves)
+	parent.Redo.CheckMoveArray2D(t, desc+".Redo", val.Redo)
+	parent.Status.CheckGameStatus(t, desc+".Status", val.Status)
 	parent.Variant.CheckVariant(t, desc+".Variant", val.Variant)
 }
 
@@ -130,9 +404,53 @@ func (parent *SavedGameSummary) CheckSavedGameSummary(t *testing.T, desc string,
 	}
 }
 
+type SolveGame struct {
+	assertion             *Assertion
+	maxNodesCheckers      []Int32Checker
+	nodesSearchedCheckers []Int32Checker
+
+	SavedGameDetail SavedGameDetail
+	Solution        MoveArray2D
+	Solvability     Solvability
+}
+
+func newSolveGame(assertion *Assertion) SolveGame {
+	return SolveGame{
+		assertion:       assertion,
+		SavedGameDetail: newSavedGameDetail(assertion),
+		Solution:        newMoveArray2D(assertion),
+		Solvability:     newSolvability(assertion),
+	}
+}
+
+func (parent *SolveGame) MaxNodes(checkers ...Int32Checker) *Assertion {
+	parent.maxNodesCheckers = checkers
+	return parent.assertion
+}
+
+func (parent *SolveGame) NodesSearched(checkers ...Int32Checker) *Assertion {
+	parent.nodesSearchedCheckers = checkers
+	return parent.assertion
+}
+
+func (parent *SolveGame) CheckSolveGame(t *testing.T, desc string, val poc.SolveGame) {
+	for _, checker := range parent.maxNodesCheckers {
+		checker.CheckInt32(t, desc+".MaxNodes", val.MaxNodes)
+	}
+	for _, checker := range parent.nodesSearchedCheckers {
+		checker.CheckInt32(t, desc+".NodesSearched", val.NodesSearched)
+	}
+	parent.SavedGameDetail.CheckSavedGameDetail(t, desc+".SavedGameDetail", val.SavedGameDetail)
+	parent.Solution.CheckMoveArray2D(t, desc+".Solution", val.Solution)
+	parent.Solvability.CheckSolvability(t, desc+".Solvability", val.Solvability)
+}
+
 type StartGame struct {
-	assertion *Assertion
+	assertion            *Assertion
+	seedCheckers         []Int64Checker
+	winnableOnlyCheckers []BoolChecker
 
+	Previous        SavedGameDetail
 	SavedGameDetail SavedGameDetail
 	Variant         Variant
 }
@@ -140,20 +458,227 @@ type StartGame struct {
 func newStartGame(assertion *Assertion) StartGame {
 	return StartGame{
 		assertion:       assertion,
+		Previous:        newSavedGameDetail(assertion),
 		SavedGameDetail: newSavedGameDetail(assertion),
 		Variant:         newVariant(assertion),
 	}
 }
 
+func (parent *StartGame) Seed(checkers ...Int64Checker) *Assertion {
+	parent.seedCheckers = checkers
+	return parent.assertion
+}
+
+func (parent *StartGame) WinnableOnly(checkers ...BoolChecker) *Assertion {
+	parent.winnableOnlyCheckers = checkers
+	return parent.assertion
+}
+
 func (parent *StartGame) CheckStartGame(t *testing.T, desc string, val poc.StartGame) {
+	for _, checker := range parent.seedCheckers {
+		checker.CheckInt64(t, desc+".Seed", val.Seed)
+	}
+	for _, checker := range parent.winnableOnlyCheckers {
+		checker.CheckBool(t, desc+".WinnableOnly", val.WinnableOnly)
+	}
+	parent.Previous.CheckSavedGameDetail(t, desc+".Previous", val.Previous)
 	parent.SavedGameDetail.CheckSavedGameDetail(t, desc+".SavedGameDetail", val.SavedGameDetail)
 	parent.Variant.CheckVariant(t, desc+".Variant", val.Variant)
 }
 
+type UndoMove struct {
+	assertion *Assertion
+
+	SavedGameDetail SavedGameDetail
+}
+
+func newUndoMove(assertion *Assertion) UndoMove {
+	return UndoMove{
+		assertion:       assertion,
+		SavedGameDetail: newSavedGameDetail(assertion),
+	}
+}
+
+func (parent *UndoMove) CheckUndoMove(t *testing.T, desc string, val poc.UndoMove) {
+	parent.SavedGameDetail.CheckSavedGameDetail(t, desc+".SavedGameDetail", val.SavedGameDetail)
+}
+
+type MoveArray1D struct {
+	assertion      *Assertion
+	lengthCheckers []IntChecker
+	nth            map[int]*Move
+
+	ForEach Move
+}
+
+func newMoveArray1D(assertion *Assertion) MoveArray1D {
+	return MoveArray1D{
+		assertion: assertion,
+		nth:       make(map[int]*Move),
+		ForEach:   newMove(assertion),
+	}
+}
+
+func (a *MoveArray1D) Nth(i int) *Move {
+	prev, ok := a.nth[i]
+	if ok {
+		return prev
+	}
+	result := newMove(a.assertion)
+	a.nth[i] = &result
+	return &result
+}
+
+func (a *MoveArray1D) Length(checkers ...IntChecker) *Assertion {
+	a.lengthCheckers = checkers
+	return a.assertion
+}
+
+func (a *MoveArray1D) CheckMoveArray1D(t *testing.T, desc string, val []poc.Move) {
+	for _, checker := range a.lengthCheckers {
+		checker.CheckInt(t, desc+".length", len(val))
+	}
+	for i, checker := range a.nth {
+		checker.CheckMove(t, desc+fmt.Sprintf("[%d]", i), val[i])
+	}
+	for _, curr := range val {
+		a.ForEach.CheckMove(t, desc+".ForEach", curr)
+	}
+}
+
+type MoveArray2D struct {
+	assertion      *Assertion
+	lengthCheckers []IntChecker
+	nth            map[int]*MoveArray1D
+
+	ForEach MoveArray1D
+}
+
+func newMoveArray2D(assertion *Assertion) MoveArray2D {
+	return MoveArray2D{
+		assertion: assertion,
+		nth:       make(map[int]*MoveArray1D),
+		ForEach:   newMoveArray1D(assertion),
+	}
+}
+
+func (a *MoveArray2D) Nth(i int) *MoveArray1D {
+	prev, ok := a.nth[i]
+	if ok {
+		return prev
+	}
+	result := newMoveArray1D(a.assertion)
+	a.nth[i] = &result
+	return &result
+}
+
+func (a *MoveArray2D) Length(checkers ...IntChecker) *Assertion {
+	a.lengthCheckers = checkers
+	return a.assertion
+}
+
+func (a *MoveArray2D) CheckMoveArray2D(t *testing.T, desc string, val [][]poc.Move) {
+	for _, checker := range a.lengthCheckers {
+		checker.CheckInt(t, desc+".length", len(val))
+	}
+	for i, checker := range a.nth {
+		checker.CheckMoveArray1D(t, desc+fmt.Sprintf("[%d]", i), val[i])
+	}
+	for _, curr := range val {
+		a.ForEach.CheckMoveArray1D(t, desc+".ForEach", curr)
+	}
+}
+
+type CardArray1D struct {
+	assertion      *Assertion
+	lengthCheckers []IntChecker
+	nth            map[int]*Card
+
+	ForEach Card
+}
+
+func newCardArray1D(assertion *Assertion) CardArray1D {
+	return CardArray1D{
+		assertion: assertion,
+		nth:       make(map[int]*Card),
+		ForEach:   newCard(assertion),
+	}
+}
+
+func (a *CardArray1D) Nth(i int) *Card {
+	prev, ok := a.nth[i]
+	if ok {
+		return prev
+	}
+	result := newCard(a.assertion)
+	a.nth[i] = &result
+	return &result
+}
+
+func (a *CardArray1D) Length(checkers ...IntChecker) *Assertion {
+	a.lengthCheckers = checkers
+	return a.assertion
+}
+
+func (a *CardArray1D) CheckCardArray1D(t *testing.T, desc string, val []poc.Card) {
+	for _, checker := range a.lengthCheckers {
+		checker.CheckInt(t, desc+".length", len(val))
+	}
+	for i, checker := range a.nth {
+		checker.CheckCard(t, desc+fmt.Sprintf("[%d]", i), val[i])
+	}
+	for _, curr := range val {
+		a.ForEach.CheckCard(t, desc+".ForEach", curr)
+	}
+}
+
+type RankedMoveArray1D struct {
+	assertion      *Assertion
+	lengthCheckers []IntChecker
+	nth            map[int]*RankedMove
+
+	ForEach RankedMove
+}
+
+func newRankedMoveArray1D(assertion *Assertion) RankedMoveArray1D {
+	return RankedMoveArray1D{
+		assertion: assertion,
+		nth:       make(map[int]*RankedMove),
+		ForEach:   newRankedMove(assertion),
+	}
+}
+
+func (a *RankedMoveArray1D) Nth(i int) *RankedMove {
+	prev, ok := a.nth[i]
+	if ok {
+		return prev
+	}
+	result := newRankedMove(a.assertion)
+	a.nth[i] = &result
+	return &result
+}
+
+func (a *RankedMoveArray1D) Length(checkers ...IntChecker) *Assertion {
+	a.lengthCheckers = checkers
+	return a.assertion
+}
+
+func (a *RankedMoveArray1D) CheckRankedMoveArray1D(t *testing.T, desc string, val []poc.RankedMove) {
+	for _, checker := range a.lengthCheckers {
+		checker.CheckInt(t, desc+".length", len(val))
+	}
+	for i, checker := range a.nth {
+		checker.CheckRankedMove(t, desc+fmt.Sprintf("[%d]", i), val[i])
+	}
+	for _, curr := range val {
+		a.ForEach.CheckRankedMove(t, desc+".ForEach", curr)
+	}
+}
+
 type SavedGameSummaryArray1D struct {
 	assertion      *Assertion
 	lengthCheckers []IntChecker
-	nth            map[int]SavedGameSummary
+	nth            map[int]*SavedGameSummary
 
 	ForEach SavedGameSummary
 }
@@ -161,19 +686,19 @@ type SavedGameSummaryArray1D struct {
 func newSavedGameSummaryArray1D(assertion *Assertion) SavedGameSummaryArray1D {
 	return SavedGameSummaryArray1D{
 		assertion: assertion,
-		nth:       make(map[int]SavedGameSummary),
+		nth:       make(map[int]*SavedGameSummary),
 		ForEach:   newSavedGameSummary(assertion),
 	}
 }
 
-func (a *SavedGameSummaryArray1D) Nth(i int) SavedGameSummary {
+func (a *SavedGameSummaryArray1D) Nth(i int) *SavedGameSummary {
 	prev, ok := a.nth[i]
 	if ok {
 		return prev
 	}
 	result := newSavedGameSummary(a.assertion)
-	a.nth[i] = result
-	return result
+	a.nth[i] = &result
+	return &result
 }
 
 func (a *SavedGameSummaryArray1D) Length(checkers ...IntChecker) *Assertion {
@@ -193,88 +718,88 @@ func (a *SavedGameSummaryArray1D) CheckSavedGameSummaryArray1D(t *testing.T, des
 	}
 }
 
-type MoveArray1D struct {
+type BoardArray1D struct {
 	assertion      *Assertion
 	lengthCheckers []IntChecker
-	nth            map[int]Move
+	nth            map[int]*Board
 
-	ForEach Move
+	ForEach Board
 }
 
-func newMoveArray1D(assertion *Assertion) MoveArray1D {
-	return MoveArray1D{
+func newBoardArray1D(assertion *Assertion) BoardArray1D {
+	return BoardArray1D{
 		assertion: assertion,
-		nth:       make(map[int]Move),
-		ForEach:   newMove(assertion),
+		nth:       make(map[int]*Board),
+		ForEach:   newBoard(assertion),
 	}
 }
 
-func (a *MoveArray1D) Nth(i int) Move {
+func (a *BoardArray1D) Nth(i int) *Board {
 	prev, ok := a.nth[i]
 	if ok {
 		return prev
 	}
-	result := newMove(a.assertion)
-	a.nth[i] = result
-	return result
+	result := newBoard(a.assertion)
+	a.nth[i] = &result
+	return &result
 }
 
-func (a *MoveArray1D) Length(checkers ...IntChecker) *Assertion {
+func (a *BoardArray1D) Length(checkers ...IntChecker) *Assertion {
 	a.lengthCheckers = checkers
 	return a.assertion
 }
 
-func (a *MoveArray1D) CheckMoveArray1D(t *testing.T, desc string, val []poc.Move) {
+func (a *BoardArray1D) CheckBoardArray1D(t *testing.T, desc string, val []poc.Board) {
 	for _, checker := range a.lengthCheckers {
 		checker.CheckInt(t, desc+".length", len(val))
 	}
 	for i, checker := range a.nth {
-		checker.CheckMove(t, desc+fmt.Sprintf("[%d]", i), val[i])
+		checker.CheckBoard(t, desc+fmt.Sprintf("[%d]", i), val[i])
 	}
 	for _, curr := range val {
-		a.ForEach.CheckMove(t, desc+".ForEach", curr)
+		a.ForEach.CheckBoard(t, desc+".ForEach", curr)
 	}
 }
 
-type MoveArray2D struct {
+type HashArray1D struct {
 	assertion      *Assertion
 	lengthCheckers []IntChecker
-	nth            map[int]MoveArray1D
+	nth            map[int]*Hash
 
-	ForEach MoveArray1D
+	ForEach Hash
 }
 
-func newMoveArray2D(assertion *Assertion) MoveArray2D {
-	return MoveArray2D{
+func newHashArray1D(assertion *Assertion) HashArray1D {
+	return HashArray1D{
 		assertion: assertion,
-		nth:       make(map[int]MoveArray1D),
-		ForEach:   newMoveArray1D(assertion),
+		nth:       make(map[int]*Hash),
+		ForEach:   newHash(assertion),
 	}
 }
 
-func (a *MoveArray2D) Nth(i int) MoveArray1D {
+func (a *HashArray1D) Nth(i int) *Hash {
 	prev, ok := a.nth[i]
 	if ok {
 		return prev
 	}
-	result := newMoveArray1D(a.assertion)
-	a.nth[i] = result
-	return result
+	result := newHash(a.assertion)
+	a.nth[i] = &result
+	return &result
 }
 
-func (a *MoveArray2D) Length(checkers ...IntChecker) *Assertion {
+func (a *HashArray1D) Length(checkers ...IntChecker) *Assertion {
 	a.lengthCheckers = checkers
 	return a.assertion
 }
 
-func (a *MoveArray2D) CheckMoveArray2D(t *testing.T, desc string, val [][]poc.Move) {
+func (a *HashArray1D) CheckHashArray1D(t *testing.T, desc string, val []poc.Hash) {
 	for _, checker := range a.lengthCheckers {
 		checker.CheckInt(t, desc+".length", len(val))
 	}
 	for i, checker := range a.nth {
-		checker.CheckMoveArray1D(t, desc+fmt.Sprintf("[%d]", i), val[i])
+		checker.CheckHash(t, desc+fmt.Sprintf("[%d]", i), val[i])
 	}
 	for _, curr := range val {
-		a.ForEach.CheckMoveArray1D(t, desc+".ForEach", curr)
+		a.ForEach.CheckHash(t, desc+".ForEach", curr)
 	}
 }
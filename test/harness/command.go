@@ -61,6 +61,222 @@ func (h PerformMove) Run(t *testing.T) {
 	}
 }
 
+type LookupGameChecker interface {
+	ErrorChecker
+	CheckLookupGame(*testing.T, string, poc.LookupGame)
+}
+
+type LookupGame []struct {
+	Desc    string
+	Input   poc.LookupGame
+	Command poc.LookupGameCaller
+	Result  LookupGameChecker
+}
+
+func (h LookupGame) Run(t *testing.T) {
+	for _, testCase := range h {
+		t.Run(testCase.Desc, func(t *testing.T) {
+			result, err := testCase.Command.CallLookupGame(context.Background(), testCase.Input)
+			if testCase.Result != nil {
+				testCase.Result.CheckError(t, "", err)
+				testCase.Result.CheckLookupGame(t, "", result)
+			}
+		})
+	}
+}
+
+type FairnessChecker interface {
+	ErrorChecker
+	CheckFairness(*testing.T, string, poc.Fairness)
+}
+
+type Fairness []struct {
+	Desc    string
+	Input   poc.Fairness
+	Command poc.FairnessCaller
+	Result  FairnessChecker
+}
+
+func (h Fairness) Run(t *testing.T) {
+	for _, testCase := range h {
+		t.Run(testCase.Desc, func(t *testing.T) {
+			result, err := testCase.Command.CallFairness(context.Background(), testCase.Input)
+			if testCase.Result != nil {
+				testCase.Result.CheckError(t, "", err)
+				testCase.Result.CheckFairness(t, "", result)
+			}
+		})
+	}
+}
+
+type ReplayChecker interface {
+	ErrorChecker
+	CheckReplay(*testing.T, string, poc.Replay)
+}
+
+type Replay []struct {
+	Desc    string
+	Input   poc.Replay
+	Command poc.ReplayCaller
+	Result  ReplayChecker
+}
+
+func (h Replay) Run(t *testing.T) {
+	for _, testCase := range h {
+		t.Run(testCase.Desc, func(t *testing.T) {
+			result, err := testCase.Command.CallReplay(context.Background(), testCase.Input)
+			if testCase.Result != nil {
+				testCase.Result.CheckError(t, "", err)
+				testCase.Result.CheckReplay(t, "", result)
+			}
+		})
+	}
+}
+
+type RedoMoveChecker interface {
+	ErrorChecker
+	CheckRedoMove(*testing.T, string, poc.RedoMove)
+}
+
+type RedoMove []struct {
+	Desc    string
+	Input   poc.RedoMove
+	Command poc.RedoMoveCaller
+	Result  RedoMoveChecker
+}
+
+func (h RedoMove) Run(t *testing.T) {
+	for _, testCase := range h {
+		t.Run(testCase.Desc, func(t *testing.T) {
+			result, err := testCase.Command.CallRedoMove(context.Background(), testCase.Input)
+			if testCase.Result != nil {
+				testCase.Result.CheckError(t, "", err)
+				testCase.Result.CheckRedoMove(t, "", result)
+			}
+		})
+	}
+}
+
+type UndoMoveChecker interface {
+	ErrorChecker
+	CheckUndoMove(*testing.T, string, poc.UndoMove)
+}
+
+type UndoMove []struct {
+	Desc    string
+	Input   poc.UndoMove
+	Command poc.UndoMoveCaller
+	Result  UndoMoveChecker
+}
+
+func (h UndoMove) Run(t *testing.T) {
+	for _, testCase := range h {
+		t.Run(testCase.Desc, func(t *testing.T) {
+			result, err := testCase.Command.CallUndoMove(context.Background(), testCase.Input)
+			if testCase.Result != nil {
+				testCase.Result.CheckError(t, "", err)
+				testCase.Result.CheckUndoMove(t, "", result)
+			}
+		})
+	}
+}
+
+type HintChecker interface {
+	ErrorChecker
+	CheckHint(*testing.T, string, poc.Hint)
+}
+
+type Hint []struct {
+	Desc    string
+	Input   poc.Hint
+	Command poc.HintCaller
+	Result  HintChecker
+}
+
+func (h Hint) Run(t *testing.T) {
+	for _, testCase := range h {
+		t.Run(testCase.Desc, func(t *testing.T) {
+			result, err := testCase.Command.CallHint(context.Background(), testCase.Input)
+			if testCase.Result != nil {
+				testCase.Result.CheckError(t, "", err)
+				testCase.Result.CheckHint(t, "", result)
+			}
+		})
+	}
+}
+
+type SolveGameChecker interface {
+	ErrorChecker
+	CheckSolveGame(*testing.T, string, poc.SolveGame)
+}
+
+type SolveGame []struct {
+	Desc    string
+	Input   poc.SolveGame
+	Command poc.SolveGameCaller
+	Result  SolveGameChecker
+}
+
+func (h SolveGame) Run(t *testing.T) {
+	for _, testCase := range h {
+		t.Run(testCase.Desc, func(t *testing.T) {
+			result, err := testCase.Command.CallSolveGame(context.Background(), testCase.Input)
+			if testCase.Result != nil {
+				testCase.Result.CheckError(t, "", err)
+				testCase.Result.CheckSolveGame(t, "", result)
+			}
+		})
+	}
+}
+
+type AutoCompleteChecker interface {
+	ErrorChecker
+	CheckAutoComplete(*testing.T, string, poc.AutoComplete)
+}
+
+type AutoComplete []struct {
+	Desc    string
+	Input   poc.AutoComplete
+	Command poc.AutoCompleteCaller
+	Result  AutoCompleteChecker
+}
+
+func (h AutoComplete) Run(t *testing.T) {
+	for _, testCase := range h {
+		t.Run(testCase.Desc, func(t *testing.T) {
+			result, err := testCase.Command.CallAutoComplete(context.Background(), testCase.Input)
+			if testCase.Result != nil {
+				testCase.Result.CheckError(t, "", err)
+				testCase.Result.CheckAutoComplete(t, "", result)
+			}
+		})
+	}
+}
+
+type ResignGameChecker interface {
+	ErrorChecker
+	CheckResignGame(*testing.T, string, poc.ResignGame)
+}
+
+type ResignGame []struct {
+	Desc    string
+	Input   poc.ResignGame
+	Command poc.ResignGameCaller
+	Result  ResignGameChecker
+}
+
+func (h ResignGame) Run(t *testing.T) {
+	for _, testCase := range h {
+		t.Run(testCase.Desc, func(t *testing.T) {
+			result, err := testCase.Command.CallResignGame(context.Background(), testCase.Input)
+			if testCase.Result != nil {
+				testCase.Result.CheckError(t, "", err)
+				testCase.Result.CheckResignGame(t, "", result)
+			}
+		})
+	}
+}
+
 type ListGamesChecker interface {
 	ErrorChecker
 	CheckListGames(*testing.T, string, poc.ListGames)
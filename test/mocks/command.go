@@ -88,6 +88,348 @@ func (mr *MockPerformMoveCallerMockRecorder) CallPerformMove(arg0, arg1 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CallPerformMove", reflect.TypeOf((*MockPerformMoveCaller)(nil).CallPerformMove), arg0, arg1)
 }
 
+// MockLookupGameCaller is a mock of LookupGameCaller interface.
+type MockLookupGameCaller struct {
+	ctrl     *gomock.Controller
+	recorder *MockLookupGameCallerMockRecorder
+}
+
+// MockLookupGameCallerMockRecorder is the mock recorder for MockLookupGameCaller.
+type MockLookupGameCallerMockRecorder struct {
+	mock *MockLookupGameCaller
+}
+
+// NewMockLookupGameCaller creates a new mock instance.
+func NewMockLookupGameCaller(ctrl *gomock.Controller) *MockLookupGameCaller {
+	mock := &MockLookupGameCaller{ctrl: ctrl}
+	mock.recorder = &MockLookupGameCallerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLookupGameCaller) EXPECT() *MockLookupGameCallerMockRecorder {
+	return m.recorder
+}
+
+// CallLookupGame mocks base method.
+func (m *MockLookupGameCaller) CallLookupGame(arg0 context.Context, arg1 poc.LookupGame) (poc.LookupGame, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CallLookupGame", arg0, arg1)
+	ret0, _ := ret[0].(poc.LookupGame)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CallLookupGame indicates an expected call of CallLookupGame.
+func (mr *MockLookupGameCallerMockRecorder) CallLookupGame(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CallLookupGame", reflect.TypeOf((*MockLookupGameCaller)(nil).CallLookupGame), arg0, arg1)
+}
+
+// MockFairnessCaller is a mock of FairnessCaller interface.
+type MockFairnessCaller struct {
+	ctrl     *gomock.Controller
+	recorder *MockFairnessCallerMockRecorder
+}
+
+// MockFairnessCallerMockRecorder is the mock recorder for MockFairnessCaller.
+type MockFairnessCallerMockRecorder struct {
+	mock *MockFairnessCaller
+}
+
+// NewMockFairnessCaller creates a new mock instance.
+func NewMockFairnessCaller(ctrl *gomock.Controller) *MockFairnessCaller {
+	mock := &MockFairnessCaller{ctrl: ctrl}
+	mock.recorder = &MockFairnessCallerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFairnessCaller) EXPECT() *MockFairnessCallerMockRecorder {
+	return m.recorder
+}
+
+// CallFairness mocks base method.
+func (m *MockFairnessCaller) CallFairness(arg0 context.Context, arg1 poc.Fairness) (poc.Fairness, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CallFairness", arg0, arg1)
+	ret0, _ := ret[0].(poc.Fairness)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CallFairness indicates an expected call of CallFairness.
+func (mr *MockFairnessCallerMockRecorder) CallFairness(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CallFairness", reflect.TypeOf((*MockFairnessCaller)(nil).CallFairness), arg0, arg1)
+}
+
+// MockReplayCaller is a mock of ReplayCaller interface.
+type MockReplayCaller struct {
+	ctrl     *gomock.Controller
+	recorder *MockReplayCallerMockRecorder
+}
+
+// MockReplayCallerMockRecorder is the mock recorder for MockReplayCaller.
+type MockReplayCallerMockRecorder struct {
+	mock *MockReplayCaller
+}
+
+// NewMockReplayCaller creates a new mock instance.
+func NewMockReplayCaller(ctrl *gomock.Controller) *MockReplayCaller {
+	mock := &MockReplayCaller{ctrl: ctrl}
+	mock.recorder = &MockReplayCallerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReplayCaller) EXPECT() *MockReplayCallerMockRecorder {
+	return m.recorder
+}
+
+// CallReplay mocks base method.
+func (m *MockReplayCaller) CallReplay(arg0 context.Context, arg1 poc.Replay) (poc.Replay, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CallReplay", arg0, arg1)
+	ret0, _ := ret[0].(poc.Replay)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CallReplay indicates an expected call of CallReplay.
+func (mr *MockReplayCallerMockRecorder) CallReplay(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CallReplay", reflect.TypeOf((*MockReplayCaller)(nil).CallReplay), arg0, arg1)
+}
+
+// MockRedoMoveCaller is a mock of RedoMoveCaller interface.
+type MockRedoMoveCaller struct {
+	ctrl     *gomock.Controller
+	recorder *MockRedoMoveCallerMockRecorder
+}
+
+// MockRedoMoveCallerMockRecorder is the mock recorder for MockRedoMoveCaller.
+type MockRedoMoveCallerMockRecorder struct {
+	mock *MockRedoMoveCaller
+}
+
+// NewMockRedoMoveCaller creates a new mock instance.
+func NewMockRedoMoveCaller(ctrl *gomock.Controller) *MockRedoMoveCaller {
+	mock := &MockRedoMoveCaller{ctrl: ctrl}
+	mock.recorder = &MockRedoMoveCallerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRedoMoveCaller) EXPECT() *MockRedoMoveCallerMockRecorder {
+	return m.recorder
+}
+
+// CallRedoMove mocks base method.
+func (m *MockRedoMoveCaller) CallRedoMove(arg0 context.Context, arg1 poc.RedoMove) (poc.RedoMove, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CallRedoMove", arg0, arg1)
+	ret0, _ := ret[0].(poc.RedoMove)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CallRedoMove indicates an expected call of CallRedoMove.
+func (mr *MockRedoMoveCallerMockRecorder) CallRedoMove(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CallRedoMove", reflect.TypeOf((*MockRedoMoveCaller)(nil).CallRedoMove), arg0, arg1)
+}
+
+// MockUndoMoveCaller is a mock of UndoMoveCaller interface.
+type MockUndoMoveCaller struct {
+	ctrl     *gomock.Controller
+	recorder *MockUndoMoveCallerMockRecorder
+}
+
+// MockUndoMoveCallerMockRecorder is the mock recorder for MockUndoMoveCaller.
+type MockUndoMoveCallerMockRecorder struct {
+	mock *MockUndoMoveCaller
+}
+
+// NewMockUndoMoveCaller creates a new mock instance.
+func NewMockUndoMoveCaller(ctrl *gomock.Controller) *MockUndoMoveCaller {
+	mock := &MockUndoMoveCaller{ctrl: ctrl}
+	mock.recorder = &MockUndoMoveCallerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUndoMoveCaller) EXPECT() *MockUndoMoveCallerMockRecorder {
+	return m.recorder
+}
+
+// CallUndoMove mocks base method.
+func (m *MockUndoMoveCaller) CallUndoMove(arg0 context.Context, arg1 poc.UndoMove) (poc.UndoMove, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CallUndoMove", arg0, arg1)
+	ret0, _ := ret[0].(poc.UndoMove)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CallUndoMove indicates an expected call of CallUndoMove.
+func (mr *MockUndoMoveCallerMockRecorder) CallUndoMove(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CallUndoMove", reflect.TypeOf((*MockUndoMoveCaller)(nil).CallUndoMove), arg0, arg1)
+}
+
+// MockHintCaller is a mock of HintCaller interface.
+type MockHintCaller struct {
+	ctrl     *gomock.Controller
+	recorder *MockHintCallerMockRecorder
+}
+
+// MockHintCallerMockRecorder is the mock recorder for MockHintCaller.
+type MockHintCallerMockRecorder struct {
+	mock *MockHintCaller
+}
+
+// NewMockHintCaller creates a new mock instance.
+func NewMockHintCaller(ctrl *gomock.Controller) *MockHintCaller {
+	mock := &MockHintCaller{ctrl: ctrl}
+	mock.recorder = &MockHintCallerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHintCaller) EXPECT() *MockHintCallerMockRecorder {
+	return m.recorder
+}
+
+// CallHint mocks base method.
+func (m *MockHintCaller) CallHint(arg0 context.Context, arg1 poc.Hint) (poc.Hint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CallHint", arg0, arg1)
+	ret0, _ := ret[0].(poc.Hint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CallHint indicates an expected call of CallHint.
+func (mr *MockHintCallerMockRecorder) CallHint(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CallHint", reflect.TypeOf((*MockHintCaller)(nil).CallHint), arg0, arg1)
+}
+
+// MockSolveGameCaller is a mock of SolveGameCaller interface.
+type MockSolveGameCaller struct {
+	ctrl     *gomock.Controller
+	recorder *MockSolveGameCallerMockRecorder
+}
+
+// MockSolveGameCallerMockRecorder is the mock recorder for MockSolveGameCaller.
+type MockSolveGameCallerMockRecorder struct {
+	mock *MockSolveGameCaller
+}
+
+// NewMockSolveGameCaller creates a new mock instance.
+func NewMockSolveGameCaller(ctrl *gomock.Controller) *MockSolveGameCaller {
+	mock := &MockSolveGameCaller{ctrl: ctrl}
+	mock.recorder = &MockSolveGameCallerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSolveGameCaller) EXPECT() *MockSolveGameCallerMockRecorder {
+	return m.recorder
+}
+
+// CallSolveGame mocks base method.
+func (m *MockSolveGameCaller) CallSolveGame(arg0 context.Context, arg1 poc.SolveGame) (poc.SolveGame, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CallSolveGame", arg0, arg1)
+	ret0, _ := ret[0].(poc.SolveGame)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CallSolveGame indicates an expected call of CallSolveGame.
+func (mr *MockSolveGameCallerMockRecorder) CallSolveGame(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CallSolveGame", reflect.TypeOf((*MockSolveGameCaller)(nil).CallSolveGame), arg0, arg1)
+}
+
+// MockAutoCompleteCaller is a mock of AutoCompleteCaller interface.
+type MockAutoCompleteCaller struct {
+	ctrl     *gomock.Controller
+	recorder *MockAutoCompleteCallerMockRecorder
+}
+
+// MockAutoCompleteCallerMockRecorder is the mock recorder for MockAutoCompleteCaller.
+type MockAutoCompleteCallerMockRecorder struct {
+	mock *MockAutoCompleteCaller
+}
+
+// NewMockAutoCompleteCaller creates a new mock instance.
+func NewMockAutoCompleteCaller(ctrl *gomock.Controller) *MockAutoCompleteCaller {
+	mock := &MockAutoCompleteCaller{ctrl: ctrl}
+	mock.recorder = &MockAutoCompleteCallerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAutoCompleteCaller) EXPECT() *MockAutoCompleteCallerMockRecorder {
+	return m.recorder
+}
+
+// CallAutoComplete mocks base method.
+func (m *MockAutoCompleteCaller) CallAutoComplete(arg0 context.Context, arg1 poc.AutoComplete) (poc.AutoComplete, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CallAutoComplete", arg0, arg1)
+	ret0, _ := ret[0].(poc.AutoComplete)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CallAutoComplete indicates an expected call of CallAutoComplete.
+func (mr *MockAutoCompleteCallerMockRecorder) CallAutoComplete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CallAutoComplete", reflect.TypeOf((*MockAutoCompleteCaller)(nil).CallAutoComplete), arg0, arg1)
+}
+
+// MockResignGameCaller is a mock of ResignGameCaller interface.
+type MockResignGameCaller struct {
+	ctrl     *gomock.Controller
+	recorder *MockResignGameCallerMockRecorder
+}
+
+// MockResignGameCallerMockRecorder is the mock recorder for MockResignGameCaller.
+type MockResignGameCallerMockRecorder struct {
+	mock *MockResignGameCaller
+}
+
+// NewMockResignGameCaller creates a new mock instance.
+func NewMockResignGameCaller(ctrl *gomock.Controller) *MockResignGameCaller {
+	mock := &MockResignGameCaller{ctrl: ctrl}
+	mock.recorder = &MockResignGameCallerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockResignGameCaller) EXPECT() *MockResignGameCallerMockRecorder {
+	return m.recorder
+}
+
+// CallResignGame mocks base method.
+func (m *MockResignGameCaller) CallResignGame(arg0 context.Context, arg1 poc.ResignGame) (poc.ResignGame, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CallResignGame", arg0, arg1)
+	ret0, _ := ret[0].(poc.ResignGame)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CallResignGame indicates an expected call of CallResignGame.
+func (mr *MockResignGameCallerMockRecorder) CallResignGame(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CallResignGame", reflect.TypeOf((*MockResignGameCaller)(nil).CallResignGame), arg0, arg1)
+}
+
 // MockListGamesCaller is a mock of ListGamesCaller interface.
 type MockListGamesCaller struct {
 	ctrl     *gomock.Controller